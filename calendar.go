@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/calendar"
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+func runCalendar(args []string) error {
+	return subcommand("calendar", args, map[string]func([]string) error{
+		"export": runCalendarExport,
+	})
+}
+
+// runCalendarExport writes planned entries, blackouts and published articles
+// to an iCalendar file for importing into a team calendar.
+func runCalendarExport(args []string) error {
+	fs, configPath := newFlagSet("calendar export")
+	output := fs.String("output", "calendar.ics", "Path of the .ics file to write (- for stdout)")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var cal *calendar.Calendar
+	if cfg.CalendarFile != "" {
+		cal, err = calendar.Load(cfg.CalendarFile)
+		if err != nil {
+			return fmt.Errorf("failed to load calendar: %w", err)
+		}
+	}
+
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	events := calendar.Events(cal, history)
+	if *output == "-" {
+		return calendar.WriteICS(os.Stdout, events, time.Now())
+	}
+
+	// #nosec G304 -- output path is provided by the user
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+	if err := calendar.WriteICS(file, events, time.Now()); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+
+	log.Printf("Wrote %d events to %s", len(events), *output)
+	return nil
+}
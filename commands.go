@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// archiveDir holds the content of published articles, one file per article ID.
+const archiveDir = "archive"
+
+// outboxDir holds generated articles that failed to publish, see outbox.go.
+const outboxDir = "outbox"
+
+// generatedDir holds a Markdown copy, with front matter, of every generated
+// article.
+const generatedDir = "generated"
+
+// commands maps subcommand names to their handlers. Running the binary
+// without a subcommand generates and publishes an article.
+var commands = map[string]func(args []string) error{
+	"calendar": runCalendar,
+	"history":  runHistory,
+	"import":   runImport,
+	"outbox":   runOutbox,
+	"publish":  runPublish,
+	"review":   runReview,
+	"topics":   runTopics,
+}
+
+// loadHistory loads the published articles from the configured store,
+// leaving out drafts in review.
+func loadHistory(cfg *config.Config) (*storage.ArticleHistory, error) {
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	history, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return history.PublishedOnly(), nil
+}
+
+// newFlagSet creates a flag set for a subcommand with the shared --config flag.
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.SetOutput(os.Stderr)
+	configPath := fs.String("config", "config.yaml", "Path to configuration file")
+	return fs, configPath
+}
+
+// subcommand picks the handler named by args[0] from handlers.
+func subcommand(group string, args []string, handlers map[string]func([]string) error) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: autoblog-ai %s <%s> [flags]", group, commandNames(handlers))
+	}
+	handler, ok := handlers[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown %s command %q (expected one of: %s)", group, args[0], commandNames(handlers))
+	}
+	return handler(args[1:])
+}
+
+func commandNames(handlers map[string]func([]string) error) string {
+	names := make([]string, 0, len(handlers))
+	for name := range handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/history"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// Output formats of the history commands.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+func runHistory(args []string) error {
+	return subcommand("history", args, map[string]func([]string) error{
+		"list":  runHistoryList,
+		"show":  runHistoryShow,
+		"stats": runHistoryStats,
+	})
+}
+
+// historyFlags are the filter and output flags shared by the history commands.
+type historyFlags struct {
+	topic, category, tag string
+	since, until         string
+	format               string
+}
+
+func addHistoryFlags(fs *flag.FlagSet) *historyFlags {
+	f := &historyFlags{}
+	fs.StringVar(&f.topic, "topic", "", "Only articles on this topic")
+	fs.StringVar(&f.category, "category", "", "Only articles in this category")
+	fs.StringVar(&f.tag, "tag", "", "Only articles with this tag (case-insensitive)")
+	fs.StringVar(&f.since, "since", "", "Only articles published on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&f.until, "until", "", "Only articles published on or before this date (YYYY-MM-DD)")
+	fs.StringVar(&f.format, "format", formatTable, "Output format: table, json or csv")
+	return f
+}
+
+// query returns the storage query selected by the filter flags.
+func (f *historyFlags) query() (storage.Query, error) {
+	q := storage.Query{Topic: f.topic, Category: f.category, Tag: f.tag, Status: storage.StatusPublished}
+	var err error
+	if f.since != "" {
+		if q.Since, err = time.ParseInLocation("2006-01-02", f.since, time.Local); err != nil {
+			return q, fmt.Errorf("invalid --since date %q, expected YYYY-MM-DD", f.since)
+		}
+	}
+	if f.until != "" {
+		if q.Until, err = time.ParseInLocation("2006-01-02", f.until, time.Local); err != nil {
+			return q, fmt.Errorf("invalid --until date %q, expected YYYY-MM-DD", f.until)
+		}
+		q.Until = q.Until.AddDate(0, 0, 1) // Include the whole day
+	}
+	return q, nil
+}
+
+func (f *historyFlags) checkFormat() error {
+	switch f.format {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (expected table, json or csv)", f.format)
+}
+
+// openHistory loads the config and opens the configured history store.
+func openHistory(configPath string) (*config.Config, storage.Store, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, store, nil
+}
+
+// runHistoryList lists published articles, newest first.
+func runHistoryList(args []string) error {
+	fs, configPath := newFlagSet("history list")
+	flags := addHistoryFlags(fs)
+	limit := fs.Int("limit", 0, "Maximum number of articles to list (0 for all)")
+	_ = fs.Parse(args)
+	if err := flags.checkFormat(); err != nil {
+		return err
+	}
+	q, err := flags.query()
+	if err != nil {
+		return err
+	}
+	q.Limit = *limit
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	articles, err := store.Query(q)
+	if err != nil {
+		return fmt.Errorf("failed to query article history: %w", err)
+	}
+
+	switch flags.format {
+	case formatJSON:
+		return writeJSON(os.Stdout, nonNil(articles))
+	case formatCSV:
+		return writeArticlesCSV(os.Stdout, articles, cfg.Pricing)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tPUBLISHED\tTOPIC\tCATEGORY\tWORDS\tTITLE")
+	for _, a := range articles {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.ID, a.PublishedAt.Format("2006-01-02"), a.Topic, a.Category, countOrDash(a.WordCount), a.Title)
+	}
+	return w.Flush()
+}
+
+// runHistoryShow prints one article record.
+func runHistoryShow(args []string) error {
+	fs, configPath := newFlagSet("history show")
+	format := fs.String("format", formatTable, "Output format: table, json or csv")
+	positional, err := parsePositional(fs, args, "article id")
+	if err != nil {
+		return err
+	}
+	if err := (&historyFlags{format: *format}).checkFormat(); err != nil {
+		return err
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	a, err := store.Get(positional[0])
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("no article with ID %q (see autoblog-ai history list)", positional[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case formatJSON:
+		return writeJSON(os.Stdout, a)
+	case formatCSV:
+		return writeArticlesCSV(os.Stdout, []storage.ArticleRecord{a}, cfg.Pricing)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	field := func(name, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", name, value)
+		}
+	}
+	field("ID", a.ID)
+	field("Title", a.Title)
+	field("Topic", a.Topic)
+	field("Category", a.Category)
+	field("Format", a.Format)
+	field("Status", a.Status)
+	field("Review note", a.ReviewNote)
+	field("Published", a.PublishedAt.Format(time.RFC3339))
+	field("URL", a.URL)
+	field("Tags", strings.Join(a.Tags, ", "))
+	field("Keywords", strings.Join(a.Keywords, ", "))
+	field("Words", countOrDash(a.WordCount))
+	field("Summary", a.Summary)
+	field("Model", a.Model)
+	if a.Usage != nil {
+		field("Tokens", fmt.Sprintf("%d in, %d out", a.Usage.InputTokens, a.Usage.OutputTokens))
+	}
+	if cost, ok := history.Cost(a, cfg.Pricing); ok {
+		field("Cost", fmt.Sprintf("$%.4f", cost))
+	}
+	field("Content", a.ContentPath)
+	field("Content hash", a.ContentHash)
+	field("Prompt hash", a.PromptHash)
+	for _, r := range a.References {
+		field("Reference", r.Document+" "+r.Location)
+	}
+	return w.Flush()
+}
+
+// runHistoryStats summarises the matching articles.
+func runHistoryStats(args []string) error {
+	fs, configPath := newFlagSet("history stats")
+	flags := addHistoryFlags(fs)
+	gaps := fs.Int("gaps", 5, "Number of longest publishing gaps to show")
+	_ = fs.Parse(args)
+	if err := flags.checkFormat(); err != nil {
+		return err
+	}
+	if *gaps < 0 {
+		return fmt.Errorf("--gaps cannot be negative")
+	}
+	q, err := flags.query()
+	if err != nil {
+		return err
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	articles, err := store.Query(q)
+	if err != nil {
+		return fmt.Errorf("failed to query article history: %w", err)
+	}
+	stats := history.Compute(articles, cfg.Pricing, time.Now(), *gaps)
+
+	switch flags.format {
+	case formatJSON:
+		return writeJSON(os.Stdout, stats)
+	case formatCSV:
+		return writeStatsCSV(os.Stdout, stats)
+	}
+	return printStats(os.Stdout, stats)
+}
+
+// printStats prints statistics as tables.
+func printStats(out io.Writer, s history.Stats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Articles:\t%d\n", s.Articles)
+	if s.Articles == 0 {
+		return w.Flush()
+	}
+	_, _ = fmt.Fprintf(w, "Published:\t%s to %s\n", s.FirstPublished.Format("2006-01-02"), s.LastPublished.Format("2006-01-02"))
+	_, _ = fmt.Fprintf(w, "Average gap:\t%.1f days (%.1f days since the last article)\n", s.AverageGapDays, s.DaysSinceLast)
+	_, _ = fmt.Fprintf(w, "Average length:\t%.0f words\n", s.AverageWords)
+	if s.PricedArticles > 0 {
+		_, _ = fmt.Fprintf(w, "Average cost:\t$%.4f (total $%.2f; %d of %d articles have recorded usage)\n", s.AverageCost, s.TotalCost, s.PricedArticles, s.Articles)
+	} else {
+		_, _ = fmt.Fprintf(w, "Average cost:\tunknown (no recorded token usage)\n")
+	}
+
+	for _, section := range []struct {
+		title  string
+		counts []history.Count
+	}{
+		{"TOPIC", s.Topics},
+		{"CATEGORY", s.Categories},
+		{"TAG", s.Tags},
+	} {
+		_, _ = fmt.Fprintf(w, "\n%s\tARTICLES\n", section.title)
+		for _, c := range section.counts {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", orDash(c.Name), c.Articles)
+		}
+	}
+
+	if len(s.LongestGaps) > 0 {
+		_, _ = fmt.Fprintln(w, "\nLONGEST GAPS\tDAYS")
+		for _, g := range s.LongestGaps {
+			_, _ = fmt.Fprintf(w, "%s to %s\t%.1f\n", g.From.Format("2006-01-02"), g.To.Format("2006-01-02"), g.Days)
+		}
+	}
+	return w.Flush()
+}
+
+// writeStatsCSV writes statistics as metric,name,value rows.
+func writeStatsCSV(out io.Writer, s history.Stats) error {
+	w := csv.NewWriter(out)
+	rows := [][]string{
+		{"metric", "name", "value"},
+		{"articles", "", strconv.Itoa(s.Articles)},
+	}
+	if s.Articles > 0 {
+		rows = append(rows,
+			[]string{"first_published", "", s.FirstPublished.Format(time.RFC3339)},
+			[]string{"last_published", "", s.LastPublished.Format(time.RFC3339)},
+			[]string{"average_gap_days", "", formatFloat(s.AverageGapDays)},
+			[]string{"days_since_last", "", formatFloat(s.DaysSinceLast)},
+			[]string{"average_words", "", formatFloat(s.AverageWords)},
+			[]string{"priced_articles", "", strconv.Itoa(s.PricedArticles)},
+			[]string{"total_cost_usd", "", formatFloat(s.TotalCost)},
+			[]string{"average_cost_usd", "", formatFloat(s.AverageCost)},
+		)
+	}
+	for _, c := range s.Topics {
+		rows = append(rows, []string{"topic", c.Name, strconv.Itoa(c.Articles)})
+	}
+	for _, c := range s.Categories {
+		rows = append(rows, []string{"category", c.Name, strconv.Itoa(c.Articles)})
+	}
+	for _, c := range s.Tags {
+		rows = append(rows, []string{"tag", c.Name, strconv.Itoa(c.Articles)})
+	}
+	for _, g := range s.LongestGaps {
+		rows = append(rows, []string{"gap_days", g.From.Format("2006-01-02") + ".." + g.To.Format("2006-01-02"), formatFloat(g.Days)})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// writeArticlesCSV writes one row per article.
+func writeArticlesCSV(out io.Writer, articles []storage.ArticleRecord, prices map[string]config.ModelPrice) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"id", "title", "topic", "category", "published_at", "url", "tags", "word_count", "model", "input_tokens", "output_tokens", "cost_usd"})
+	for _, a := range articles {
+		var input, output, cost string
+		if a.Usage != nil {
+			input, output = strconv.Itoa(a.Usage.InputTokens), strconv.Itoa(a.Usage.OutputTokens)
+		}
+		if c, ok := history.Cost(a, prices); ok {
+			cost = formatFloat(c)
+		}
+		_ = w.Write([]string{a.ID, a.Title, a.Topic, a.Category, a.PublishedAt.Format(time.RFC3339), a.URL,
+			strings.Join(a.Tags, ";"), strconv.Itoa(a.WordCount), a.Model, input, output, cost})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// nonNil makes an empty result encode as [] rather than null.
+func nonNil(articles []storage.ArticleRecord) []storage.ArticleRecord {
+	if articles == nil {
+		return []storage.ArticleRecord{}
+	}
+	return articles
+}
+
+// formatFloat formats a number for CSV, to four decimal places at most.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e4)/1e4, 'f', -1, 64)
+}
+
+func countOrDash(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.Itoa(n)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
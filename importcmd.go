@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/medium"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+	"github.com/yourusername/autoblog-ai/internal/topics"
+)
+
+func runImport(args []string) error {
+	return subcommand("import", args, map[string]func([]string) error{
+		"json":          runImportJSON,
+		"medium-export": runImportMediumExport,
+	})
+}
+
+// runImportJSON copies the articles of a JSON history file into the
+// configured store, skipping articles it already holds.
+func runImportJSON(args []string) error {
+	fs, configPath := newFlagSet("import json")
+	from := fs.String("from", "articles.json", "JSON history file to import")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Storage.Backend == config.StorageJSON && filepath.Clean(cfg.Storage.Path) == filepath.Clean(*from) {
+		return fmt.Errorf("%s is already the configured history store", *from)
+	}
+
+	source, err := storage.NewJSONStore(*from).Load()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", *from, err)
+	}
+
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	imported, skipped := 0, 0
+	err = store.Update(func(history *storage.ArticleHistory) error {
+		imported, skipped = 0, 0 // Update may retry
+		stored := make(map[string]bool, len(history.Articles))
+		for _, a := range history.Articles {
+			stored[a.ID] = true
+		}
+		for _, a := range source.Articles {
+			if stored[a.ID] {
+				skipped++
+				continue
+			}
+			stored[a.ID] = true
+			history.Articles = append(history.Articles, a)
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import into %s: %w", cfg.Storage.Path, err)
+	}
+
+	log.Printf("Imported %d articles from %s into %s (%d already present)", imported, *from, cfg.Storage.Path, skipped)
+	return nil
+}
+
+// runImportMediumExport adds the published posts of a Medium account export
+// to the history, so duplicate checks and topic cooldowns account for
+// articles written before autoblog-ai. Posts are attributed to the
+// configured topic they match best.
+func runImportMediumExport(args []string) error {
+	fs, configPath := newFlagSet("import medium-export")
+	topicName := fs.String("topic", "", "Attribute every post to this topic instead of matching topics")
+	dryRun := fs.Bool("dry-run", false, "List the posts that would be imported without saving them")
+	positional, err := parsePositional(fs, args, "export.zip")
+	if err != nil {
+		return err
+	}
+	zipPath := positional[0]
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	posts, err := medium.ReadExport(zipPath)
+	if err != nil {
+		return err
+	}
+
+	records := make([]storage.ArticleRecord, 0, len(posts))
+	contents := make(map[string]string, len(posts))
+	for _, post := range posts {
+		record := storage.ArticleRecord{
+			ID:          storage.RecordID(post.Title, post.PublishedAt),
+			Title:       post.Title,
+			Topic:       *topicName,
+			PublishedAt: post.PublishedAt,
+			URL:         post.CanonicalURL,
+			Tags:        post.Tags,
+			Fingerprint: article.Fingerprint(post.Title, post.Markdown),
+			Summary:     post.Subtitle,
+		}
+		if record.Summary == "" {
+			record.Summary = article.Summarize(post.Markdown)
+		}
+		if *topicName == "" {
+			if t, keywords, ok := topics.Match(cfg.Topics, post.Title, post.Tags, post.Markdown); ok {
+				record.Topic, record.Category, record.Keywords = t.Name, t.Category, keywords
+			}
+		}
+		records = append(records, record)
+		contents[record.ID] = post.Markdown
+	}
+
+	if *dryRun {
+		for _, r := range records {
+			fmt.Printf("%s  %-30s  %s\n", r.PublishedAt.Format("2006-01-02"), orDash(r.Topic), r.Title)
+		}
+		log.Printf("Dry run: %d posts found in %s", len(records), zipPath)
+		return nil
+	}
+
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return err
+	}
+
+	imported, skipped := 0, 0
+	err = store.Update(func(history *storage.ArticleHistory) error {
+		imported, skipped = 0, 0 // Update may retry
+		stored := make(map[string]bool, 2*len(history.Articles))
+		for _, a := range history.Articles {
+			stored[a.ID] = true
+			if a.URL != "" {
+				stored[a.URL] = true
+			}
+		}
+		for _, record := range records {
+			if stored[record.ID] || (record.URL != "" && stored[record.URL]) {
+				skipped++
+				continue
+			}
+			if err := archive.Put(&record, contents[record.ID]); err != nil {
+				return fmt.Errorf("failed to archive %q: %w", record.Title, err)
+			}
+			stored[record.ID] = true
+			history.Articles = append(history.Articles, record)
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import into %s: %w", cfg.Storage.Path, err)
+	}
+
+	log.Printf("Imported %d Medium posts from %s into %s (%d already present)", imported, zipPath, cfg.Storage.Path, skipped)
+	return nil
+}
@@ -0,0 +1,136 @@
+package article
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// Document is a local source file attached to a generation request as a
+// Messages API document content block.
+type Document struct {
+	Title     string
+	Path      string
+	MediaType string // "application/pdf" or "text/plain"
+	Data      []byte
+}
+
+// Citation is a passage of a source document that the model cited while
+// writing the article.
+type Citation struct {
+	DocumentIndex int
+	DocumentTitle string
+	CitedText     string
+	Location      string // Human-readable location, e.g. "p. 3" or "chars 120-480"
+}
+
+// LoadDocument reads a PDF, Markdown or plain-text file from disk.
+func LoadDocument(path string) (*Document, error) {
+	var mediaType string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		mediaType = "application/pdf"
+	case ".md", ".markdown", ".txt", ".text", "":
+		mediaType = "text/plain"
+	default:
+		return nil, fmt.Errorf("unsupported source file type: %s", path)
+	}
+
+	// #nosec G304 -- path is provided by the user as source material
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("source file is empty: %s", path)
+	}
+	if mediaType == "text/plain" && !utf8.Valid(data) {
+		return nil, fmt.Errorf("source file is not valid UTF-8 text: %s", path)
+	}
+
+	return &Document{
+		Title:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Path:      path,
+		MediaType: mediaType,
+		Data:      data,
+	}, nil
+}
+
+// contentBlock converts the document into a Messages API document block
+// with citations enabled.
+func (d *Document) contentBlock() map[string]any {
+	source := map[string]any{"media_type": d.MediaType}
+	if d.MediaType == "application/pdf" {
+		source["type"] = "base64"
+		source["data"] = base64.StdEncoding.EncodeToString(d.Data)
+	} else {
+		source["type"] = "text"
+		source["data"] = string(d.Data)
+	}
+
+	return map[string]any{
+		"type":      "document",
+		"source":    source,
+		"title":     d.Title,
+		"citations": map[string]bool{"enabled": true},
+	}
+}
+
+// renderReferences formats citations as a Markdown "References" section,
+// grouping cited passages under the document they came from.
+func renderReferences(citations []Citation) string {
+	if len(citations) == 0 {
+		return ""
+	}
+
+	var order []string
+	passages := make(map[string][]Citation)
+	for _, c := range citations {
+		if _, ok := passages[c.DocumentTitle]; !ok {
+			order = append(order, c.DocumentTitle)
+		}
+		passages[c.DocumentTitle] = append(passages[c.DocumentTitle], c)
+	}
+
+	var b strings.Builder
+	b.WriteString("## References\n\n")
+	for i, title := range order {
+		b.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, title))
+		seen := make(map[string]bool)
+		for _, c := range passages[title] {
+			text := strings.Join(strings.Fields(c.CitedText), " ")
+			if text == "" || seen[text] {
+				continue
+			}
+			seen[text] = true
+			if c.Location != "" {
+				b.WriteString(fmt.Sprintf("   - \"%s\" (%s)\n", text, c.Location))
+			} else {
+				b.WriteString(fmt.Sprintf("   - \"%s\"\n", text))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// References returns the article's citations in the form stored in history.
+func (a *Article) References() []storage.Reference {
+	if len(a.Citations) == 0 {
+		return nil
+	}
+	refs := make([]storage.Reference, 0, len(a.Citations))
+	for _, c := range a.Citations {
+		refs = append(refs, storage.Reference{
+			Document:  c.DocumentTitle,
+			CitedText: c.CitedText,
+			Location:  c.Location,
+		})
+	}
+	return refs
+}
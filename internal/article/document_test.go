@@ -0,0 +1,207 @@
+package article
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestLoadDocument(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name          string
+		file          string
+		content       []byte
+		wantMediaType string
+		wantErr       bool
+	}{
+		{
+			name:          "markdown",
+			file:          "rfc.md",
+			content:       []byte("# RFC\n\nSome text"),
+			wantMediaType: "text/plain",
+		},
+		{
+			name:          "pdf",
+			file:          "paper.pdf",
+			content:       []byte("%PDF-1.4 fake"),
+			wantMediaType: "application/pdf",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "image.png",
+			content: []byte{0x89, 'P', 'N', 'G'},
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			file:    "empty.txt",
+			content: []byte{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid utf-8 text",
+			file:    "binary.txt",
+			content: []byte{0xff, 0xfe, 0xfd},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.file)
+			if err := os.WriteFile(path, tt.content, 0600); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			doc, err := LoadDocument(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if doc.MediaType != tt.wantMediaType {
+				t.Errorf("MediaType = %v, want %v", doc.MediaType, tt.wantMediaType)
+			}
+			if doc.Title != tt.file[:len(tt.file)-len(filepath.Ext(tt.file))] {
+				t.Errorf("Title = %v, want file name without extension", doc.Title)
+			}
+		})
+	}
+}
+
+func TestDocumentContentBlock(t *testing.T) {
+	pdf := &Document{Title: "paper", MediaType: "application/pdf", Data: []byte("abc")}
+	block := pdf.contentBlock()
+	source := block["source"].(map[string]any)
+	if source["type"] != "base64" || source["data"] != "YWJj" {
+		t.Errorf("PDF source = %v, want base64 encoded data", source)
+	}
+
+	text := &Document{Title: "notes", MediaType: "text/plain", Data: []byte("hello")}
+	block = text.contentBlock()
+	source = block["source"].(map[string]any)
+	if source["type"] != "text" || source["data"] != "hello" {
+		t.Errorf("text source = %v, want plain text data", source)
+	}
+	if block["citations"].(map[string]bool)["enabled"] != true {
+		t.Error("citations should be enabled")
+	}
+}
+
+func TestRenderReferences(t *testing.T) {
+	if got := renderReferences(nil); got != "" {
+		t.Errorf("renderReferences(nil) = %q, want empty", got)
+	}
+
+	got := renderReferences([]Citation{
+		{DocumentTitle: "RFC 9110", CitedText: "GET is safe.", Location: "chars 0-12"},
+		{DocumentTitle: "Paper", CitedText: "Results  improved.", Location: "p. 3"},
+		{DocumentTitle: "RFC 9110", CitedText: "GET is safe."},
+	})
+
+	expected := []string{
+		"## References",
+		"1. **RFC 9110**",
+		"\"GET is safe.\" (chars 0-12)",
+		"2. **Paper**",
+		"\"Results improved.\" (p. 3)",
+	}
+	for _, want := range expected {
+		if !contains(got, want) {
+			t.Errorf("renderReferences() missing %q in:\n%s", want, got)
+		}
+	}
+	if count := strings.Count(got, "GET is safe."); count != 1 {
+		t.Errorf("renderReferences() repeated a cited passage %d times, want 1", count)
+	}
+}
+
+func TestGenerate_WithDocuments(t *testing.T) {
+	mockResponse := `{
+		"content": [
+			{"type": "text", "text": "{\"title\": \"HTTP Semantics\", \"content\": \"# HTTP\\n\\nGET is safe."},
+			{"type": "text", "text": " Really.\", \"tags\": [\"http\"]}", "citations": [
+				{"type": "page_location", "cited_text": "GET is safe", "document_index": 0, "document_title": "rfc9110", "start_page_number": 4, "end_page_number": 5}
+			]}
+		]
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Messages []struct {
+				Content []map[string]any `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+
+		blocks := reqBody.Messages[0].Content
+		if len(blocks) != 2 {
+			t.Fatalf("content blocks = %d, want document + text", len(blocks))
+		}
+		if blocks[0]["type"] != "document" || blocks[1]["type"] != "text" {
+			t.Errorf("unexpected block types: %v, %v", blocks[0]["type"], blocks[1]["type"])
+		}
+		if !contains(blocks[1]["text"].(string), "rfc9110") {
+			t.Error("prompt should list the attached source documents")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "rfc9110.pdf")
+	if err := os.WriteFile(sourcePath, []byte("%PDF-1.4"), 0600); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+	doc, err := LoadDocument(sourcePath)
+	if err != nil {
+		t.Fatalf("LoadDocument() error = %v", err)
+	}
+
+	temp := 1.0
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			Model:          "claude-sonnet-4-20250514",
+			MaxTokens:      8192,
+			Temperature:    &temp,
+			TimeoutSeconds: 120,
+		},
+	}
+
+	gen := newTestGenerator("test-api-key", cfg, server.URL)
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{}}
+
+	article, err := gen.Generate(t.Context(), "HTTP", history, WithDocuments(doc))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(article.Citations) != 1 {
+		t.Fatalf("Citations = %d, want 1", len(article.Citations))
+	}
+	if article.Citations[0].Location != "p. 4" {
+		t.Errorf("Citation location = %q, want %q", article.Citations[0].Location, "p. 4")
+	}
+	if !contains(article.Content, "## References") || !contains(article.Content, "\"GET is safe\" (p. 4)") {
+		t.Errorf("Content should end with a References section, got:\n%s", article.Content)
+	}
+
+	refs := article.References()
+	if len(refs) != 1 || refs[0].Document != "rfc9110" {
+		t.Errorf("References() = %+v, want one reference to rfc9110", refs)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"math"
 	"net/http"
+	"os"
 	"strings"
 	"text/template"
 	"time"
@@ -24,11 +25,109 @@ type Article struct {
 	Content     string
 	Tags        []string
 	PublishedAt time.Time
+	Citations   []Citation
+	Keywords    []string // Topic keywords the article covered
+
+	// Generation metadata recorded in the article history
+	Model      string
+	PromptHash string // "sha256:<hex>" of the prompt template, empty for the built-in prompt
+	Format     string // Editorial format the article was asked for
+	Usage      storage.Usage
 }
 
 // Generator is an interface for generating articles using AI.
 type Generator interface {
-	Generate(ctx context.Context, topic string, history *storage.ArticleHistory) (*Article, error)
+	Generate(ctx context.Context, topic string, history *storage.ArticleHistory, opts ...GenerateOption) (*Article, error)
+}
+
+// Completer sends free-form prompts to the model, for tasks other than
+// article generation such as planning topics.
+type Completer interface {
+	Complete(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+}
+
+// GenerateOption customizes a single Generate call.
+type GenerateOption func(*generateOptions)
+
+// generateOptions holds per-call settings collected from GenerateOption values.
+type generateOptions struct {
+	documents    []*Document
+	codeMap      string
+	releaseNotes string
+	templatePath string
+	format       string
+	theme        string
+	keywords     []string // Keyword subset chosen for this article
+	tooSimilarTo []string // Published articles an earlier draft was too similar to
+}
+
+// WithCodeMap supplies a condensed map of a code base's exported API so the
+// article explains real APIs with accurate snippets.
+func WithCodeMap(codeMap string) GenerateOption {
+	return func(o *generateOptions) {
+		o.codeMap = codeMap
+	}
+}
+
+// WithReleaseNotes supplies structured release notes for a release announcement.
+func WithReleaseNotes(notes string) GenerateOption {
+	return func(o *generateOptions) {
+		o.releaseNotes = notes
+	}
+}
+
+// WithEditorial applies the article format and theme planned in the
+// editorial calendar.
+func WithEditorial(format, theme string) GenerateOption {
+	return func(o *generateOptions) {
+		o.format = format
+		o.theme = theme
+	}
+}
+
+// WithDistinctFrom asks for an article clearly different from the given
+// published articles, after a draft came out too similar to them.
+func WithDistinctFrom(titles ...string) GenerateOption {
+	return func(o *generateOptions) {
+		o.tooSimilarTo = append(o.tooSimilarTo, titles...)
+	}
+}
+
+// WithPromptTemplate uses the template at path instead of the configured
+// prompt template.
+func WithPromptTemplate(path string) GenerateOption {
+	return func(o *generateOptions) {
+		o.templatePath = path
+	}
+}
+
+// sourceTitles returns the titles of the attached documents. It is safe to
+// call on a nil receiver.
+func (o *generateOptions) sourceTitles() []string {
+	if o == nil {
+		return nil
+	}
+	titles := make([]string, 0, len(o.documents))
+	for _, doc := range o.documents {
+		titles = append(titles, doc.Title)
+	}
+	return titles
+}
+
+// topicKeywords returns the rotated keyword subset if one was chosen, or
+// else all of the topic's keywords. It is safe to call on a nil receiver.
+func (o *generateOptions) topicKeywords(topicDetails *config.TopicConfig) []string {
+	if o != nil && o.keywords != nil {
+		return o.keywords
+	}
+	return topicDetails.Keywords
+}
+
+// WithDocuments attaches source documents that the model should draw on and cite.
+func WithDocuments(docs ...*Document) GenerateOption {
+	return func(o *generateOptions) {
+		o.documents = append(o.documents, docs...)
+	}
 }
 
 // claudeGenerator is the concrete implementation of Generator using Claude API.
@@ -50,6 +149,12 @@ type PromptData struct {
 	TargetAudience   string
 	IncludeCode      bool
 	PreviousTitles   []string
+	Sources          []string // Titles of attached source documents
+	CodeMap          string   // Condensed code map for source-code articles
+	ReleaseNotes     string   // Structured release notes for release announcements
+	Format           string   // Planned article format, e.g. "tutorial"
+	Theme            string   // Editorial theme the article should fit
+	TooSimilarTo     []string // Published articles an earlier draft was too similar to
 }
 
 // NewGenerator creates a new article generator with the specified API key and configuration.
@@ -77,8 +182,23 @@ func NewGeneratorWithLogger(apiKey string, cfg *config.Config, logger *slog.Logg
 	}
 }
 
+// NewCompleter creates a Completer that shares the generator's model settings and retry logic.
+func NewCompleter(apiKey string, cfg *config.Config) Completer {
+	return NewGenerator(apiKey, cfg).(*claudeGenerator)
+}
+
+// Complete sends a single prompt and returns the model's text response.
+func (g *claudeGenerator) Complete(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return g.callClaudeAPIWithRetry(ctx, systemPrompt, userPrompt)
+}
+
 // Generate creates a new article with context support for cancellation.
-func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *storage.ArticleHistory) (*Article, error) {
+func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *storage.ArticleHistory, opts ...GenerateOption) (*Article, error) {
+	o := &generateOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	logger := g.logger.With(
 		"topic", topic,
 		"previous_articles_count", len(history.Articles),
@@ -109,9 +229,32 @@ func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *s
 		logger.WarnContext(ctx, "No topic details found for topic")
 	}
 
+	// Rotate through the topic's keywords so articles cover different ground
+	if topicDetails != nil && len(topicDetails.Keywords) > 0 {
+		o.keywords = rotateKeywords(topicDetails.Keywords, history.KeywordCoverage(topic), g.config.Style.KeywordsPerArticle)
+		logger.InfoContext(ctx, "Selected keywords for this article",
+			"keywords", o.keywords,
+			"available", len(topicDetails.Keywords))
+	}
+
+	// Attach per-topic source documents
+	if topicDetails != nil {
+		for _, path := range topicDetails.Sources {
+			doc, err := LoadDocument(path)
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to load topic source", "path", path, "error", err)
+				return nil, fmt.Errorf("failed to load topic source: %w", err)
+			}
+			o.documents = append(o.documents, doc)
+		}
+	}
+	if len(o.documents) > 0 {
+		logger.InfoContext(ctx, "Attaching source documents", "count", len(o.documents))
+	}
+
 	// Build the prompt using template
 	logger.DebugContext(ctx, "Building prompt from template")
-	prompt := g.buildPromptFromTemplate(topic, topicDetails, previousTitles)
+	prompt, promptHash := g.buildPrompt(topic, topicDetails, previousTitles, o)
 
 	// Get system prompt
 	systemPrompt := g.getSystemPrompt()
@@ -120,7 +263,7 @@ func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *s
 	logger.InfoContext(ctx, "Calling Claude API",
 		"model", g.config.AI.Model,
 		"max_tokens", g.config.AI.MaxTokens)
-	response, err := g.callClaudeAPIWithRetry(ctx, systemPrompt, prompt)
+	response, err := g.sendMessageWithRetry(ctx, systemPrompt, g.userContent(prompt, o.documents))
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to call Claude API",
 			"error", err)
@@ -129,15 +272,30 @@ func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *s
 
 	// Parse the response
 	logger.DebugContext(ctx, "Parsing Claude API response")
-	article, err := g.parseResponse(response)
+	article, err := g.parseResponse(response.Text)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to parse Claude response",
 			"error", err,
-			"response_length", len(response))
+			"response_length", len(response.Text))
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	if len(response.Citations) > 0 {
+		article.Citations = response.Citations
+		article.Content = strings.TrimRight(article.Content, "\n") + "\n\n" + renderReferences(response.Citations)
+		logger.InfoContext(ctx, "Rendered references from citations",
+			"citations", len(response.Citations))
+	}
+
+	if topicDetails != nil {
+		article.Keywords = coveredKeywords(topicDetails.Keywords, o.keywords, article.Content)
+	}
+
 	article.PublishedAt = time.Now()
+	article.Model = g.config.AI.Model
+	article.Format = o.format
+	article.Usage = response.Usage
+	article.PromptHash = promptHash
 	logger.InfoContext(ctx, "Successfully generated article",
 		"title", article.Title,
 		"content_length", len(article.Content),
@@ -146,14 +304,32 @@ func (g *claudeGenerator) Generate(ctx context.Context, topic string, history *s
 	return article, nil
 }
 
-func (g *claudeGenerator) buildPromptFromTemplate(topic string, topicDetails *config.TopicConfig, previousTitles []string) string {
+// promptTemplatePath returns the template used for the article prompt.
+func (g *claudeGenerator) promptTemplatePath(o *generateOptions) string {
+	if o != nil && o.templatePath != "" {
+		return o.templatePath
+	}
+	return g.config.GetPromptTemplatePath()
+}
+
+func (g *claudeGenerator) buildPromptFromTemplate(topic string, topicDetails *config.TopicConfig, previousTitles []string, o *generateOptions) string {
+	prompt, _ := g.buildPrompt(topic, topicDetails, previousTitles, o)
+	return prompt
+}
+
+// buildPrompt builds the article prompt from the template, or the built-in
+// prompt when the template cannot be used. templateHash is the hash of the
+// template the prompt came from, empty for the built-in prompt.
+func (g *claudeGenerator) buildPrompt(topic string, topicDetails *config.TopicConfig, previousTitles []string, o *generateOptions) (prompt, templateHash string) {
 	// Load template
-	templateContent, err := g.config.GetPromptTemplate()
+	templatePath := g.promptTemplatePath(o)
+	// #nosec G304 -- template path comes from config or command line
+	templateContent, err := os.ReadFile(templatePath)
 	if err != nil {
 		g.logger.Warn("Failed to load prompt template, falling back to built-in",
-			"template_path", g.config.GetPromptTemplatePath(),
+			"template_path", templatePath,
 			"error", err)
-		return g.buildPromptFallback(topic, topicDetails, previousTitles)
+		return g.buildPromptFallback(topic, topicDetails, previousTitles, o), ""
 	}
 
 	// Parse template
@@ -161,23 +337,32 @@ func (g *claudeGenerator) buildPromptFromTemplate(topic string, topicDetails *co
 	if err != nil {
 		g.logger.Warn("Failed to parse prompt template, falling back to built-in",
 			"error", err)
-		return g.buildPromptFallback(topic, topicDetails, previousTitles)
+		return g.buildPromptFallback(topic, topicDetails, previousTitles, o), ""
 	}
 
 	// Prepare data
+	style := g.config.StyleFor(topicDetails)
 	data := PromptData{
 		Topic:          topic,
-		Tone:           g.config.Style.Tone,
-		Length:         g.config.Style.Length,
-		TargetAudience: g.config.Style.TargetAudience,
-		IncludeCode:    g.config.Style.IncludeCode,
+		Tone:           style.Tone,
+		Length:         style.Length,
+		TargetAudience: style.TargetAudience,
+		IncludeCode:    style.IncludeCode,
 		PreviousTitles: previousTitles,
+		Sources:        o.sourceTitles(),
+	}
+	if o != nil {
+		data.CodeMap = o.codeMap
+		data.ReleaseNotes = o.releaseNotes
+		data.Format = o.format
+		data.Theme = o.theme
+		data.TooSimilarTo = o.tooSimilarTo
 	}
 
 	if topicDetails != nil {
 		data.TopicDescription = topicDetails.Description
-		if len(topicDetails.Keywords) > 0 {
-			data.Keywords = strings.Join(topicDetails.Keywords, ", ")
+		if keywords := o.topicKeywords(topicDetails); len(keywords) > 0 {
+			data.Keywords = strings.Join(keywords, ", ")
 		}
 	}
 
@@ -186,35 +371,63 @@ func (g *claudeGenerator) buildPromptFromTemplate(topic string, topicDetails *co
 	if err := tmpl.Execute(&buf, data); err != nil {
 		g.logger.Warn("Failed to execute prompt template, falling back to built-in",
 			"error", err)
-		return g.buildPromptFallback(topic, topicDetails, previousTitles)
+		return g.buildPromptFallback(topic, topicDetails, previousTitles, o), ""
 	}
 
 	g.logger.Debug("Successfully built prompt from template",
 		"prompt_length", buf.Len())
-	return buf.String()
+	return buf.String(), storage.ContentHash(templateContent)
 }
 
-func (g *claudeGenerator) buildPromptFallback(topic string, topicDetails *config.TopicConfig, previousTitles []string) string {
+func (g *claudeGenerator) buildPromptFallback(topic string, topicDetails *config.TopicConfig, previousTitles []string, o *generateOptions) string {
 	var prompt strings.Builder
+	style := g.config.StyleFor(topicDetails)
 
 	prompt.WriteString("You are a technical writer creating an engaging article for Medium. ")
-	prompt.WriteString(fmt.Sprintf("Write a %s article about: %s\n\n", g.config.Style.Length, topic))
+	prompt.WriteString(fmt.Sprintf("Write a %s article about: %s\n\n", style.Length, topic))
 
 	if topicDetails != nil && topicDetails.Description != "" {
 		prompt.WriteString(fmt.Sprintf("Focus area: %s\n\n", topicDetails.Description))
-		if len(topicDetails.Keywords) > 0 {
-			prompt.WriteString(fmt.Sprintf("Include these concepts: %s\n\n", strings.Join(topicDetails.Keywords, ", ")))
+		if keywords := o.topicKeywords(topicDetails); len(keywords) > 0 {
+			prompt.WriteString(fmt.Sprintf("Include these concepts: %s\n\n", strings.Join(keywords, ", ")))
 		}
 	}
 
+	if o != nil && o.format != "" {
+		prompt.WriteString(fmt.Sprintf("Format: write this as a %s\n\n", o.format))
+	}
+	if o != nil && o.theme != "" {
+		prompt.WriteString(fmt.Sprintf("Editorial theme: %s (tie the article to this theme)\n\n", o.theme))
+	}
+
 	prompt.WriteString("Style requirements:\n")
-	prompt.WriteString(fmt.Sprintf("- Tone: %s\n", g.config.Style.Tone))
-	prompt.WriteString(fmt.Sprintf("- Target audience: %s\n", g.config.Style.TargetAudience))
-	if g.config.Style.IncludeCode {
+	prompt.WriteString(fmt.Sprintf("- Tone: %s\n", style.Tone))
+	prompt.WriteString(fmt.Sprintf("- Target audience: %s\n", style.TargetAudience))
+	if style.IncludeCode {
 		prompt.WriteString("- Include practical code examples\n")
 	}
 	prompt.WriteString("\n")
 
+	if sources := o.sourceTitles(); len(sources) > 0 {
+		prompt.WriteString("Base the article on the attached source documents and cite them where you rely on them:\n")
+		for _, title := range sources {
+			prompt.WriteString(fmt.Sprintf("- %s\n", title))
+		}
+		prompt.WriteString("\n")
+	}
+
+	if o != nil && o.releaseNotes != "" {
+		prompt.WriteString("Write a release announcement covering these release notes. Lead with the most user-visible changes and do not invent features that are not listed:\n\n")
+		prompt.WriteString(o.releaseNotes)
+		prompt.WriteString("\n")
+	}
+
+	if o != nil && o.codeMap != "" {
+		prompt.WriteString("Explain the code base described by this code map. Only use APIs that appear in it and keep code snippets accurate to the signatures shown:\n\n")
+		prompt.WriteString(o.codeMap)
+		prompt.WriteString("\n\n")
+	}
+
 	if len(previousTitles) > 0 {
 		prompt.WriteString("Previously written articles on this topic (avoid duplicating):\n")
 		for _, title := range previousTitles {
@@ -223,6 +436,14 @@ func (g *claudeGenerator) buildPromptFallback(topic string, topicDetails *config
 		prompt.WriteString("\n")
 	}
 
+	if o != nil && len(o.tooSimilarTo) > 0 {
+		prompt.WriteString("An earlier draft was too similar to these published articles. Take a clearly different angle, structure and examples:\n")
+		for _, title := range o.tooSimilarTo {
+			prompt.WriteString(fmt.Sprintf("- %s\n", title))
+		}
+		prompt.WriteString("\n")
+	}
+
 	prompt.WriteString("Article requirements:\n")
 	prompt.WriteString("1. Create a compelling, SEO-friendly title\n")
 	prompt.WriteString("2. Write the article in Markdown format\n")
@@ -250,8 +471,37 @@ func (g *claudeGenerator) getSystemPrompt() string {
 	return string(content)
 }
 
+// messageResponse is the text and citations extracted from a Messages API response.
+type messageResponse struct {
+	Text      string
+	Citations []Citation
+	Usage     storage.Usage
+}
+
+// userContent builds the user message content: a plain string when there are
+// no documents, otherwise document blocks followed by the prompt text block.
+func (g *claudeGenerator) userContent(prompt string, docs []*Document) any {
+	if len(docs) == 0 {
+		return prompt
+	}
+	blocks := make([]map[string]any, 0, len(docs)+1)
+	for _, doc := range docs {
+		blocks = append(blocks, doc.contentBlock())
+	}
+	return append(blocks, map[string]any{"type": "text", "text": prompt})
+}
+
 // callClaudeAPIWithRetry calls the Claude API with exponential backoff retry logic.
 func (g *claudeGenerator) callClaudeAPIWithRetry(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	response, err := g.sendMessageWithRetry(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return "", err
+	}
+	return response.Text, nil
+}
+
+// sendMessageWithRetry sends a message with exponential backoff retry logic.
+func (g *claudeGenerator) sendMessageWithRetry(ctx context.Context, systemPrompt string, content any) (*messageResponse, error) {
 	const maxRetries = 3
 	var lastErr error
 
@@ -269,11 +519,11 @@ func (g *claudeGenerator) callClaudeAPIWithRetry(ctx context.Context, systemProm
 			case <-ctx.Done():
 				g.logger.WarnContext(ctx, "Context cancelled during retry backoff",
 					"attempt", attempt+1)
-				return "", ctx.Err()
+				return nil, ctx.Err()
 			}
 		}
 
-		response, err := g.callClaudeAPI(ctx, systemPrompt, userPrompt)
+		response, err := g.sendMessage(ctx, systemPrompt, content)
 		if err == nil {
 			if attempt > 0 {
 				g.logger.InfoContext(ctx, "API call succeeded after retry",
@@ -289,7 +539,7 @@ func (g *claudeGenerator) callClaudeAPIWithRetry(ctx context.Context, systemProm
 			g.logger.WarnContext(ctx, "Non-retryable error encountered",
 				"attempt", attempt+1,
 				"error", err)
-			return "", err
+			return nil, err
 		}
 
 		g.logger.WarnContext(ctx, "Retryable error encountered",
@@ -300,7 +550,7 @@ func (g *claudeGenerator) callClaudeAPIWithRetry(ctx context.Context, systemProm
 	g.logger.ErrorContext(ctx, "Max retries exceeded",
 		"max_attempts", maxRetries,
 		"last_error", lastErr)
-	return "", fmt.Errorf("max retries exceeded: %w", lastErr)
+	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
 // isRetryableError determines if an error should be retried.
@@ -319,6 +569,16 @@ func isRetryableError(err error) bool {
 }
 
 func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	response, err := g.sendMessage(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return "", err
+	}
+	return response.Text, nil
+}
+
+// sendMessage performs a single Messages API request. content is either a
+// string or a slice of content blocks.
+func (g *claudeGenerator) sendMessage(ctx context.Context, systemPrompt string, content any) (*messageResponse, error) {
 	// Get temperature value (default to 1.0 if nil)
 	temperature := 1.0
 	if g.config.AI.Temperature != nil {
@@ -330,10 +590,10 @@ func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userP
 		"max_tokens":  g.config.AI.MaxTokens,
 		"temperature": temperature,
 		"system":      systemPrompt,
-		"messages": []map[string]string{
+		"messages": []map[string]any{
 			{
 				"role":    "user",
-				"content": userPrompt,
+				"content": content,
 			},
 		},
 	}
@@ -341,7 +601,7 @@ func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userP
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
 		g.logger.ErrorContext(ctx, "Failed to marshal request body", "error", err)
-		return "", err
+		return nil, err
 	}
 
 	g.logger.DebugContext(ctx, "Sending request to Claude API",
@@ -353,7 +613,7 @@ func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userP
 	req, err := http.NewRequestWithContext(ctx, "POST", g.apiURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		g.logger.ErrorContext(ctx, "Failed to create HTTP request", "error", err)
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -368,7 +628,7 @@ func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userP
 		g.logger.ErrorContext(ctx, "HTTP request failed",
 			"error", err,
 			"duration_ms", duration.Milliseconds())
-		return "", err
+		return nil, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -381,38 +641,81 @@ func (g *claudeGenerator) callClaudeAPI(ctx context.Context, systemPrompt, userP
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		g.logger.ErrorContext(ctx, "Failed to read response body", "error", err)
-		return "", err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		g.logger.ErrorContext(ctx, "API returned non-OK status",
 			"status_code", resp.StatusCode,
 			"response_body", string(body))
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var response struct {
 		Content []struct {
-			Text string `json:"text"`
+			Type      string `json:"type"`
+			Text      string `json:"text"`
+			Citations []struct {
+				Type            string `json:"type"`
+				CitedText       string `json:"cited_text"`
+				DocumentIndex   int    `json:"document_index"`
+				DocumentTitle   string `json:"document_title"`
+				StartPageNumber int    `json:"start_page_number"`
+				EndPageNumber   int    `json:"end_page_number"`
+				StartCharIndex  int    `json:"start_char_index"`
+				EndCharIndex    int    `json:"end_char_index"`
+			} `json:"citations"`
 		} `json:"content"`
+		Usage storage.Usage `json:"usage"`
 	}
 
 	if err := json.Unmarshal(body, &response); err != nil {
 		g.logger.ErrorContext(ctx, "Failed to unmarshal API response",
 			"error", err,
 			"response_body", string(body))
-		return "", err
+		return nil, err
 	}
 
 	if len(response.Content) == 0 {
 		g.logger.ErrorContext(ctx, "API response contains no content")
-		return "", fmt.Errorf("no content in response")
+		return nil, fmt.Errorf("no content in response")
+	}
+
+	// With citations enabled the answer is split across several text blocks,
+	// each carrying the citations for its span.
+	result := &messageResponse{Usage: response.Usage}
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type != "" && block.Type != "text" {
+			continue
+		}
+		text.WriteString(block.Text)
+		for _, c := range block.Citations {
+			citation := Citation{
+				DocumentIndex: c.DocumentIndex,
+				DocumentTitle: c.DocumentTitle,
+				CitedText:     c.CitedText,
+			}
+			switch c.Type {
+			case "page_location":
+				if c.EndPageNumber-1 > c.StartPageNumber {
+					citation.Location = fmt.Sprintf("pp. %d-%d", c.StartPageNumber, c.EndPageNumber-1)
+				} else {
+					citation.Location = fmt.Sprintf("p. %d", c.StartPageNumber)
+				}
+			case "char_location":
+				citation.Location = fmt.Sprintf("chars %d-%d", c.StartCharIndex, c.EndCharIndex)
+			}
+			result.Citations = append(result.Citations, citation)
+		}
 	}
+	result.Text = text.String()
 
 	g.logger.DebugContext(ctx, "Successfully received content from API",
-		"response_length", len(response.Content[0].Text))
+		"response_length", len(result.Text),
+		"citations", len(result.Citations))
 
-	return response.Content[0].Text, nil
+	return result, nil
 }
 
 func (g *claudeGenerator) parseResponse(response string) (*Article, error) {
@@ -443,4 +746,7 @@ func (g *claudeGenerator) parseResponse(response string) (*Article, error) {
 	}, nil
 }
 
-var _ Generator = &claudeGenerator{}
+var (
+	_ Generator = &claudeGenerator{}
+	_ Completer = &claudeGenerator{}
+)
@@ -125,7 +125,7 @@ func TestBuildPromptFromTemplate(t *testing.T) {
 	}
 	previousTitles := []string{"Previous Article 1"}
 
-	prompt := gen.buildPromptFromTemplate(topic, topicDetails, previousTitles)
+	prompt := gen.buildPromptFromTemplate(topic, topicDetails, previousTitles, nil)
 
 	// Should fall back to built-in prompt since template file doesn't exist in test
 	if prompt == "" {
@@ -175,7 +175,7 @@ Previous: {{.}}
 	}
 	previousTitles := []string{"Old Title 1", "Old Title 2"}
 
-	prompt := gen.buildPromptFromTemplate(topic, topicDetails, previousTitles)
+	prompt := gen.buildPromptFromTemplate(topic, topicDetails, previousTitles, nil)
 
 	// Verify all template variables were filled
 	expectedStrings := []string{
@@ -218,7 +218,7 @@ func TestBuildPromptFromTemplate_InvalidTemplateSyntax(t *testing.T) {
 	gen := NewGenerator("test-key", cfg).(*claudeGenerator)
 
 	// Should fall back to built-in template on parse error
-	prompt := gen.buildPromptFromTemplate("Test Topic", nil, nil)
+	prompt := gen.buildPromptFromTemplate("Test Topic", nil, nil, nil)
 
 	if prompt == "" {
 		t.Error("buildPromptFromTemplate() should return fallback prompt")
@@ -433,7 +433,7 @@ func TestBuildPromptFallback(t *testing.T) {
 	}
 	previousTitles := []string{"Old Article"}
 
-	prompt := gen.buildPromptFallback(topic, topicDetails, previousTitles)
+	prompt := gen.buildPromptFallback(topic, topicDetails, previousTitles, nil)
 
 	if prompt == "" {
 		t.Error("buildPromptFallback() returned empty prompt")
@@ -855,3 +855,204 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestBuildPrompt_WithCodeMap(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "prompt.md")
+	if err := os.WriteFile(templatePath, []byte("Topic: {{.Topic}}\n{{if .CodeMap}}Code:\n{{.CodeMap}}{{end}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	cfg := &config.Config{PromptTemplate: templatePath}
+	gen := NewGenerator("test-key", cfg).(*claudeGenerator)
+
+	o := &generateOptions{}
+	WithCodeMap("func New(rate int) *Limiter")(o)
+
+	prompt := gen.buildPromptFromTemplate("How ratelimit works", nil, nil, o)
+	if !contains(prompt, "Code:\nfunc New(rate int) *Limiter") {
+		t.Errorf("template prompt missing code map:\n%s", prompt)
+	}
+
+	fallback := gen.buildPromptFallback("How ratelimit works", nil, nil, o)
+	if !contains(fallback, "func New(rate int) *Limiter") || !contains(fallback, "Only use APIs") {
+		t.Errorf("fallback prompt missing code map:\n%s", fallback)
+	}
+}
+
+func TestBuildPrompt_WithReleaseTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	articlePath := filepath.Join(tmpDir, "article.md")
+	releasePath := filepath.Join(tmpDir, "release.md")
+	if err := os.WriteFile(articlePath, []byte("Article: {{.Topic}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if err := os.WriteFile(releasePath, []byte("Release: {{.Topic}}\n{{.ReleaseNotes}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	cfg := &config.Config{PromptTemplate: articlePath}
+	gen := NewGenerator("test-key", cfg).(*claudeGenerator)
+
+	o := &generateOptions{}
+	WithReleaseNotes("Version: 1.1.0\n\nAdded:\n- Code maps")(o)
+	WithPromptTemplate(releasePath)(o)
+
+	prompt := gen.buildPromptFromTemplate("Release v1.1.0", nil, nil, o)
+	if !contains(prompt, "Release: Release v1.1.0") || !contains(prompt, "- Code maps") {
+		t.Errorf("prompt should use the release template, got:\n%s", prompt)
+	}
+
+	o.templatePath = filepath.Join(tmpDir, "missing.md")
+	fallback := gen.buildPromptFromTemplate("Release v1.1.0", nil, nil, o)
+	if !contains(fallback, "release announcement") || !contains(fallback, "- Code maps") {
+		t.Errorf("fallback prompt should include release notes, got:\n%s", fallback)
+	}
+}
+
+func TestBuildPrompt_WithEditorial(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "prompt.md")
+	if err := os.WriteFile(templatePath, []byte("{{.Topic}}|{{.Format}}|{{.Theme}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	cfg := &config.Config{PromptTemplate: templatePath}
+	gen := NewGenerator("test-key", cfg).(*claudeGenerator)
+
+	o := &generateOptions{}
+	WithEditorial("tutorial", "Observability")(o)
+
+	if prompt := gen.buildPromptFromTemplate("Tracing", nil, nil, o); prompt != "Tracing|tutorial|Observability" {
+		t.Errorf("template prompt = %q", prompt)
+	}
+
+	fallback := gen.buildPromptFallback("Tracing", nil, nil, o)
+	if !contains(fallback, "write this as a tutorial") || !contains(fallback, "Editorial theme: Observability") {
+		t.Errorf("fallback prompt missing editorial direction:\n%s", fallback)
+	}
+}
+
+func TestBuildPrompt_WithDistinctFrom(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "prompt.md")
+	if err := os.WriteFile(templatePath, []byte("{{range .TooSimilarTo}}[{{.}}]{{end}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	cfg := &config.Config{PromptTemplate: templatePath}
+	gen := NewGenerator("test-key", cfg).(*claudeGenerator)
+
+	o := &generateOptions{}
+	WithDistinctFrom("Go Channels 101")(o)
+	WithDistinctFrom("Channels in Practice")(o)
+
+	if prompt := gen.buildPromptFromTemplate("Channels", nil, nil, o); prompt != "[Go Channels 101][Channels in Practice]" {
+		t.Errorf("template prompt = %q", prompt)
+	}
+	if fallback := gen.buildPromptFallback("Channels", nil, nil, o); !contains(fallback, "too similar") || !contains(fallback, "- Go Channels 101") {
+		t.Errorf("fallback prompt missing distinct-from direction:\n%s", fallback)
+	}
+}
+
+func TestGenerate_RotatesKeywords(t *testing.T) {
+	mockResponse := `{
+		"content": [{
+			"text": "{\"title\": \"Context Done Right\", \"content\": \"# Context\\n\\nCancel with context, guard state with sync.\", \"tags\": [\"go\"]}"
+		}]
+	}`
+
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		prompt = reqBody.Messages[0].Content
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	temp := 1.0
+	cfg := &config.Config{
+		AI: config.AIConfig{Model: "claude-sonnet-4-20250514", MaxTokens: 8192, Temperature: &temp, TimeoutSeconds: 120},
+		Topics: []config.TopicConfig{{
+			Name:        "Go Concurrency",
+			Description: "Concurrency patterns",
+			Keywords:    []string{"goroutines", "channels", "context", "sync"},
+			Weight:      1,
+		}},
+		Style: config.StyleConfig{Tone: "professional", Length: "medium", KeywordsPerArticle: 2},
+	}
+	gen := newTestGenerator("test-api-key", cfg, server.URL)
+
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "Go Concurrency", Title: "Channels 101", Keywords: []string{"goroutines", "channels"}},
+	}}
+
+	article, err := gen.Generate(t.Context(), "Go Concurrency", history)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !contains(prompt, "Include these concepts: context, sync") || contains(prompt, "goroutines") {
+		t.Errorf("prompt should focus on uncovered keywords:\n%s", prompt)
+	}
+	if len(article.Keywords) != 2 || article.Keywords[0] != "context" || article.Keywords[1] != "sync" {
+		t.Errorf("Keywords = %v, want [context sync]", article.Keywords)
+	}
+}
+
+func TestGenerate_RecordsMetadata(t *testing.T) {
+	mockResponse := `{
+		"content": [{
+			"text": "{\"title\": \"Tracing\", \"content\": \"# Tracing\\n\\nSpans everywhere.\", \"tags\": [\"go\"]}"
+		}],
+		"usage": {"input_tokens": 1200, "output_tokens": 800}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	templatePath := filepath.Join(t.TempDir(), "prompt.md")
+	if err := os.WriteFile(templatePath, []byte("Write about {{.Topic}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	temp := 1.0
+	cfg := &config.Config{
+		AI:             config.AIConfig{Model: "claude-sonnet-4-20250514", MaxTokens: 8192, Temperature: &temp, TimeoutSeconds: 120},
+		PromptTemplate: templatePath,
+	}
+	gen := newTestGenerator("test-api-key", cfg, server.URL)
+
+	article, err := gen.Generate(t.Context(), "Tracing", &storage.ArticleHistory{}, WithEditorial("tutorial", ""))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if article.Model != "claude-sonnet-4-20250514" || article.Format != "tutorial" {
+		t.Errorf("Model, Format = %q, %q", article.Model, article.Format)
+	}
+	if article.Usage != (storage.Usage{InputTokens: 1200, OutputTokens: 800}) {
+		t.Errorf("Usage = %+v", article.Usage)
+	}
+	if article.PromptHash != storage.ContentHash([]byte("Write about {{.Topic}}")) {
+		t.Errorf("PromptHash = %q, want the template's hash", article.PromptHash)
+	}
+
+	// A template that cannot be parsed falls back to the built-in prompt
+	if err := os.WriteFile(templatePath, []byte("Write about {{.Topic"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	article, err = gen.Generate(t.Context(), "Tracing", &storage.ArticleHistory{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if article.PromptHash != "" {
+		t.Errorf("PromptHash = %q for the built-in prompt, want empty", article.PromptHash)
+	}
+}
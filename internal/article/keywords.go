@@ -0,0 +1,77 @@
+package article
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rotateKeywords picks up to n keywords, preferring ones covered by the
+// fewest previous articles. Equally covered keywords keep their configured
+// order, and the result is returned in configured order. n <= 0, such as
+// config.KeywordsAll, keeps all keywords.
+func rotateKeywords(keywords []string, coverage map[string]int, n int) []string {
+	if n <= 0 || n >= len(keywords) {
+		return keywords
+	}
+
+	order := make([]int, len(keywords))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return coverage[strings.ToLower(keywords[order[a]])] < coverage[strings.ToLower(keywords[order[b]])]
+	})
+
+	chosen := order[:n]
+	sort.Ints(chosen)
+	subset := make([]string, 0, n)
+	for _, i := range chosen {
+		subset = append(subset, keywords[i])
+	}
+	return subset
+}
+
+// coveredKeywords returns the keywords an article covered: the ones it was
+// asked to focus on, plus any other topic keywords mentioned in its content.
+func coveredKeywords(all, requested []string, content string) []string {
+	lower := strings.ToLower(content)
+	want := make(map[string]bool, len(requested))
+	for _, kw := range requested {
+		want[kw] = true
+	}
+
+	var covered []string
+	for _, kw := range all {
+		if want[kw] || containsTerm(lower, strings.ToLower(kw)) {
+			covered = append(covered, kw)
+		}
+	}
+	return covered
+}
+
+// containsTerm reports whether term occurs in text as a whole word or phrase,
+// so short keywords such as "AI" do not match inside "email".
+func containsTerm(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], term)
+		if i == -1 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,65 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+func TestRotateKeywords(t *testing.T) {
+	keywords := []string{"goroutines", "channels", "context", "sync", "patterns"}
+
+	tests := []struct {
+		name     string
+		coverage map[string]int
+		n        int
+		want     string
+	}{
+		{name: "no history keeps configured order", coverage: nil, n: 3, want: "goroutines,channels,context"},
+		{name: "prefers uncovered keywords", coverage: map[string]int{"goroutines": 2, "channels": 1}, n: 3, want: "context,sync,patterns"},
+		{name: "least covered fill the rest", coverage: map[string]int{"goroutines": 2, "channels": 1, "context": 1, "sync": 1, "patterns": 3}, n: 2, want: "channels,context"},
+		{name: "coverage is case-insensitive", coverage: map[string]int{"goroutines": 1}, n: 1, want: "channels"},
+		{name: "all keeps all", coverage: map[string]int{"sync": 1}, n: config.KeywordsAll, want: "goroutines,channels,context,sync,patterns"},
+		{name: "zero keeps all", coverage: map[string]int{"sync": 1}, n: 0, want: "goroutines,channels,context,sync,patterns"},
+		{name: "n above length keeps all", n: 10, want: "goroutines,channels,context,sync,patterns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(rotateKeywords(keywords, tt.coverage, tt.n), ",")
+			if got != tt.want {
+				t.Errorf("rotateKeywords() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoveredKeywords(t *testing.T) {
+	all := []string{"AI", "vector search", "embeddings", "RAG"}
+	content := "We send each email through an embeddings model and store it for Vector Search."
+
+	got := strings.Join(coveredKeywords(all, []string{"RAG"}, content), ",")
+	if got != "vector search,embeddings,RAG" {
+		t.Errorf("coveredKeywords() = %s, want requested plus mentioned keywords (not AI in email)", got)
+	}
+}
+
+func TestContainsTerm(t *testing.T) {
+	tests := []struct {
+		text, term string
+		want       bool
+	}{
+		{"use c++ templates", "c++", true},
+		{"emails and ai", "ai", true},
+		{"emails", "ai", false},
+		{"goroutines", "go", false},
+		{"go, rust", "go", true},
+		{"anything", "", false},
+	}
+	for _, tt := range tests {
+		if got := containsTerm(tt.text, tt.term); got != tt.want {
+			t.Errorf("containsTerm(%q, %q) = %v, want %v", tt.text, tt.term, got, tt.want)
+		}
+	}
+}
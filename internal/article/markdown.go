@@ -0,0 +1,193 @@
+package article
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter opens and closes the YAML front matter of a
+// Markdown article.
+const frontMatterDelimiter = "---"
+
+// maxSlugLength caps the length of a slug, in runes.
+const maxSlugLength = 80
+
+// FrontMatter is the YAML header of an article saved as Markdown.
+type FrontMatter struct {
+	Title        string    `yaml:"title"`
+	Slug         string    `yaml:"slug,omitempty"`
+	Tags         []string  `yaml:"tags,omitempty"`
+	Topic        string    `yaml:"topic,omitempty"`
+	Date         time.Time `yaml:"date,omitempty"`
+	Model        string    `yaml:"model,omitempty"`
+	Status       string    `yaml:"status,omitempty"` // draft, pending or published
+	CanonicalURL string    `yaml:"canonical_url,omitempty"`
+}
+
+// NewFrontMatter returns the front matter of a generated article.
+func NewFrontMatter(a *Article, topic string) FrontMatter {
+	return FrontMatter{
+		Title: a.Title,
+		Slug:  Slugify(a.Title),
+		Tags:  a.Tags,
+		Topic: topic,
+		Date:  a.PublishedAt,
+		Model: a.Model,
+	}
+}
+
+// ParseMarkdown reads an article saved as Markdown: optional YAML front
+// matter between "---" lines, then the body. Without a title in the front
+// matter, the first "# " heading is used. The article's date and model come
+// from the front matter, so a saved article keeps its history ID.
+func ParseMarkdown(data []byte) (*Article, FrontMatter, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\ufeff"))), "\r\n", "\n")
+
+	var meta FrontMatter
+	body := text
+	if rest, ok := strings.CutPrefix(text, frontMatterDelimiter+"\n"); ok {
+		header, after, found := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+		if !found {
+			header, found = strings.CutSuffix(rest, "\n"+frontMatterDelimiter)
+		}
+		if !found {
+			return nil, meta, fmt.Errorf("front matter is not closed with %q", frontMatterDelimiter)
+		}
+		if err := yaml.Unmarshal([]byte(header), &meta); err != nil {
+			return nil, meta, fmt.Errorf("invalid front matter: %w", err)
+		}
+		body = after
+	}
+	body = strings.TrimLeft(body, "\n")
+
+	title := strings.TrimSpace(meta.Title)
+	if title == "" {
+		for _, line := range strings.Split(body, "\n") {
+			if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+				title = strings.TrimSpace(heading)
+				break
+			}
+		}
+	}
+	if title == "" {
+		return nil, meta, fmt.Errorf("article has no title: add one to the front matter or a \"# \" heading")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, meta, fmt.Errorf("article %q has no content", title)
+	}
+
+	return &Article{Title: title, Content: body, Tags: meta.Tags, PublishedAt: meta.Date, Model: meta.Model}, meta, nil
+}
+
+// LoadMarkdown reads an article saved as Markdown, see ParseMarkdown.
+func LoadMarkdown(path string) (*Article, FrontMatter, error) {
+	// #nosec G304 -- path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, FrontMatter{}, err
+	}
+	a, meta, err := ParseMarkdown(data)
+	if err != nil {
+		return nil, meta, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, meta, nil
+}
+
+// FormatMarkdown renders an article's content with its front matter, in
+// the format ParseMarkdown reads.
+func FormatMarkdown(meta FrontMatter, content string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(meta); err != nil {
+		return nil, fmt.Errorf("failed to encode front matter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode front matter: %w", err)
+	}
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(content)
+	return buf.Bytes(), nil
+}
+
+// SaveMarkdown writes an article with its front matter to a new file in dir,
+// named after its date and slug, and returns the file's path. It never
+// overwrites a file: when the name is taken, a counter is added to it.
+func SaveMarkdown(dir string, meta FrontMatter, content string) (string, error) {
+	data, err := FormatMarkdown(meta, content)
+	if err != nil {
+		return "", err
+	}
+	slug := meta.Slug
+	if slug == "" {
+		slug = Slugify(meta.Title)
+	}
+	date := meta.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	base := date.UTC().Format("2006-01-02") + "-" + slug
+
+	for n := 1; ; n++ {
+		name := base + ".md"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.md", base, n)
+		}
+		path := filepath.Join(dir, name)
+		// #nosec G304 -- path is built from a slug under dir
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(data); err != nil {
+			_ = f.Close()
+			return "", err
+		}
+		return path, f.Close()
+	}
+}
+
+// Slugify turns a title into a lowercase slug of words joined by hyphens.
+// Letters, marks and digits of any script are kept, so non-English titles
+// keep a meaningful slug; a title without any gives "article". Long slugs
+// are cut at a word boundary.
+func Slugify(title string) string {
+	// "Go's" becomes "gos", not "go-s"
+	title = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(title))
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+
+	slug := ""
+	for _, word := range words {
+		next := word
+		if slug != "" {
+			next = slug + "-" + word
+		}
+		if utf8.RuneCountInString(next) > maxSlugLength {
+			if slug == "" {
+				slug = string([]rune(word)[:maxSlugLength])
+			}
+			break
+		}
+		slug = next
+	}
+	if slug == "" {
+		return "article"
+	}
+	return slug
+}
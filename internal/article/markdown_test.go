@@ -0,0 +1,180 @@
+package article
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantTitle string
+		wantBody  string
+		wantMeta  FrontMatter
+	}{
+		{
+			name:      "front matter",
+			data:      "---\ntitle: Go Channels\ntags: [go, concurrency]\ntopic: Advanced Go\n---\n\nChannels connect goroutines.\n",
+			wantTitle: "Go Channels",
+			wantBody:  "Channels connect goroutines.\n",
+			wantMeta:  FrontMatter{Title: "Go Channels", Tags: []string{"go", "concurrency"}, Topic: "Advanced Go"},
+		},
+		{
+			name:      "title from heading",
+			data:      "# Testing in Go\n\nTable tests.\n",
+			wantTitle: "Testing in Go",
+			wantBody:  "# Testing in Go\n\nTable tests.\n",
+		},
+		{
+			name:      "front matter without title, CRLF",
+			data:      "---\r\ntags:\r\n  - go\r\n---\r\n# Heading\r\nBody\r\n",
+			wantTitle: "Heading",
+			wantBody:  "# Heading\nBody\n",
+			wantMeta:  FrontMatter{Tags: []string{"go"}},
+		},
+		{
+			name:      "horizontal rule is not front matter",
+			data:      "# Title\n\n---\n\nBody",
+			wantTitle: "Title",
+			wantBody:  "# Title\n\n---\n\nBody",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, meta, err := ParseMarkdown([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseMarkdown() error = %v", err)
+			}
+			if a.Title != tt.wantTitle || a.Content != tt.wantBody {
+				t.Errorf("ParseMarkdown() = %q, %q; want %q, %q", a.Title, a.Content, tt.wantTitle, tt.wantBody)
+			}
+			if !reflect.DeepEqual(meta, tt.wantMeta) {
+				t.Errorf("front matter = %+v, want %+v", meta, tt.wantMeta)
+			}
+			if !reflect.DeepEqual(a.Tags, tt.wantMeta.Tags) {
+				t.Errorf("Tags = %v, want %v", a.Tags, tt.wantMeta.Tags)
+			}
+		})
+	}
+}
+
+func TestParseMarkdown_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unclosed front matter", "---\ntitle: x\nbody", "not closed"},
+		{"invalid yaml", "---\ntitle: [x\n---\nbody", "invalid front matter"},
+		{"no title", "Just text", "no title"},
+		{"no content", "---\ntitle: Empty\n---\n", "no content"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseMarkdown([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseMarkdown() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMarkdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.md")
+	if err := os.WriteFile(path, []byte("# Title\n\nBody"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if a, _, err := LoadMarkdown(path); err != nil || a.Title != "Title" {
+		t.Errorf("LoadMarkdown() = %v, %v", a, err)
+	}
+	if _, _, err := LoadMarkdown(filepath.Join(t.TempDir(), "missing.md")); err == nil {
+		t.Error("LoadMarkdown() of a missing file should fail")
+	}
+}
+
+func TestFormatMarkdown_RoundTrip(t *testing.T) {
+	a := &Article{
+		Title:       "Go's Channels: A Guide",
+		Content:     "# Go's Channels\n\nChannels connect goroutines.\n",
+		Tags:        []string{"go", "concurrency"},
+		PublishedAt: time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC),
+		Model:       "claude-sonnet-4-5",
+	}
+	meta := NewFrontMatter(a, "Advanced Go")
+	meta.Status, meta.CanonicalURL = "published", "https://medium.com/@me/go-channels"
+
+	data, err := FormatMarkdown(meta, a.Content)
+	if err != nil {
+		t.Fatalf("FormatMarkdown() error = %v", err)
+	}
+	for _, want := range []string{"slug: gos-channels-a-guide\n", "date: 2025-05-01T09:30:00Z\n", "canonical_url: https://medium.com/@me/go-channels\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("FormatMarkdown() =\n%s\nwant it to contain %q", data, want)
+		}
+	}
+
+	got, gotMeta, err := ParseMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotMeta, meta) {
+		t.Errorf("front matter = %+v, want %+v", gotMeta, meta)
+	}
+	if got.Title != a.Title || got.Content != a.Content || !got.PublishedAt.Equal(a.PublishedAt) || got.Model != a.Model {
+		t.Errorf("ParseMarkdown() = %+v, want %+v", got, a)
+	}
+}
+
+func TestSaveMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	meta := FrontMatter{Title: "Go Channels", Slug: "go-channels", Date: time.Date(2025, 5, 1, 23, 0, 0, 0, time.FixedZone("", -3*3600))}
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := SaveMarkdown(dir, meta, "Body "+strings.Repeat("!", i))
+		if err != nil {
+			t.Fatalf("SaveMarkdown() error = %v", err)
+		}
+		paths = append(paths, filepath.Base(path))
+	}
+	want := []string{"2025-05-02-go-channels.md", "2025-05-02-go-channels-2.md", "2025-05-02-go-channels-3.md"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("SaveMarkdown() paths = %v, want %v", paths, want)
+	}
+
+	a, _, err := LoadMarkdown(filepath.Join(dir, want[0]))
+	if err != nil || a.Title != "Go Channels" || a.Content != "Body " {
+		t.Errorf("LoadMarkdown() = %+v, %v", a, err)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Understanding Go Channels", "understanding-go-channels"},
+		{"  Go's  Context -- Explained!  ", "gos-context-explained"},
+		{"Café au lait & Crème brûlée", "café-au-lait-crème-brûlée"},
+		{"Straße über Größe", "straße-über-größe"},
+		{"Go 1.25: What's New?", "go-1-25-whats-new"},
+		{"Go の並行処理", "go-の並行処理"},
+		{"Привет, мир", "привет-мир"},
+		{"?!", "article"},
+		{"", "article"},
+		{strings.Repeat("word ", 30), strings.TrimSuffix(strings.Repeat("word-", 16), "-")},
+		{strings.Repeat("x", 100), strings.Repeat("x", 80)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := Slugify(tt.title); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
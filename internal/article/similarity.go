@@ -0,0 +1,115 @@
+package article
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+const (
+	// shingleSize is the number of consecutive words hashed together.
+	shingleSize = 3
+	// signatureSize is the number of MinHash values in a fingerprint.
+	signatureSize = 64
+)
+
+// minHashSeeds are the per-slot seeds of the MinHash hash family, derived
+// once so fingerprints stay comparable across runs.
+var minHashSeeds = func() [signatureSize]uint64 {
+	var seeds [signatureSize]uint64
+	state := uint64(0x5eeda1b10c)
+	for i := range seeds {
+		state += 0x9e3779b97f4a7c15
+		seeds[i] = mix64(state)
+	}
+	return seeds
+}()
+
+// mix64 is the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Fingerprint returns a MinHash signature of an article's title and body
+// over overlapping three-word shingles. The fraction of equal values in two
+// fingerprints estimates the Jaccard similarity of the articles' shingle
+// sets. Empty text gives a nil fingerprint.
+func Fingerprint(title, content string) []uint32 {
+	words := strings.FieldsFunc(strings.ToLower(title+"\n"+content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil
+	}
+
+	var shingles []uint64
+	for i := 0; i+shingleSize <= len(words) || i == 0; i++ {
+		end := min(i+shingleSize, len(words))
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(words[i:end], " ")))
+		shingles = append(shingles, h.Sum64())
+	}
+
+	signature := make([]uint32, signatureSize)
+	for i, seed := range minHashSeeds {
+		lowest := ^uint64(0)
+		for _, s := range shingles {
+			lowest = min(lowest, mix64(s^seed))
+		}
+		signature[i] = uint32(lowest >> 32)
+	}
+	return signature
+}
+
+// Similarity estimates the Jaccard similarity, from 0 to 1, of the articles
+// two fingerprints were taken from.
+func Similarity(a, b []uint32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// Match is the published article most similar to a draft.
+type Match struct {
+	Article    storage.ArticleRecord
+	Similarity float64
+}
+
+// NearestArticle compares a draft fingerprint with every article in history
+// and returns the most similar one. Articles recorded without a fingerprint
+// are fingerprinted from the body returned by content, if any; ok is false
+// when no article could be compared.
+func NearestArticle(fingerprint []uint32, history *storage.ArticleHistory, content func(storage.ArticleRecord) string) (match Match, ok bool) {
+	if history == nil {
+		return Match{}, false
+	}
+	for _, a := range history.Articles {
+		stored := a.Fingerprint
+		if len(stored) == 0 && content != nil {
+			if body := content(a); body != "" {
+				stored = Fingerprint(a.Title, body)
+			}
+		}
+		if len(stored) == 0 {
+			continue
+		}
+		if s := Similarity(fingerprint, stored); !ok || s > match.Similarity {
+			match, ok = Match{Article: a, Similarity: s}, true
+		}
+	}
+	return match, ok
+}
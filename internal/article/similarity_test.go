@@ -0,0 +1,79 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+const channelsBody = `Channels are the pipes that connect concurrent goroutines. You can send values
+into channels from one goroutine and receive those values into another goroutine.
+Buffered channels accept a limited number of values without a corresponding receiver.
+Closing a channel indicates that no more values will be sent on it, and a range loop
+over the channel stops once it has been drained. Select lets a goroutine wait on
+several channel operations at once and is the building block for timeouts.`
+
+func TestFingerprint(t *testing.T) {
+	if Fingerprint("", "  ... ") != nil {
+		t.Error("Fingerprint() of text without words should be nil")
+	}
+	if got := len(Fingerprint("Go", "")); got != signatureSize {
+		t.Errorf("len(Fingerprint()) = %d, want %d", got, signatureSize)
+	}
+
+	base := Fingerprint("Go Channels", channelsBody)
+	if s := Similarity(base, Fingerprint("Go Channels", channelsBody)); s != 1 {
+		t.Errorf("identical articles similarity = %v, want 1", s)
+	}
+	// Case and punctuation do not matter
+	if s := Similarity(base, Fingerprint("GO CHANNELS!", strings.ToUpper(channelsBody))); s != 1 {
+		t.Errorf("case-changed article similarity = %v, want 1", s)
+	}
+
+	edited := strings.Replace(channelsBody, "is the building block for timeouts", "makes timeouts easy to write", 1)
+	if s := Similarity(base, Fingerprint("Go Channels", edited)); s < 0.6 {
+		t.Errorf("lightly edited article similarity = %v, want >= 0.6", s)
+	}
+
+	other := Fingerprint("Structured Logging", `The slog package provides structured logging with levels.
+Handlers decide how records are formatted, for example as JSON or as text key value pairs.`)
+	if s := Similarity(base, other); s > 0.1 {
+		t.Errorf("unrelated article similarity = %v, want <= 0.1", s)
+	}
+}
+
+func TestSimilarity_Mismatched(t *testing.T) {
+	if s := Similarity(nil, nil); s != 0 {
+		t.Errorf("Similarity(nil, nil) = %v, want 0", s)
+	}
+	if s := Similarity([]uint32{1, 2}, []uint32{1}); s != 0 {
+		t.Errorf("Similarity() of different lengths = %v, want 0", s)
+	}
+}
+
+func TestNearestArticle(t *testing.T) {
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Title: "Structured Logging", Fingerprint: Fingerprint("Structured Logging", "slog handlers records levels attributes")},
+		{Title: "Go Channels"}, // Recorded before fingerprints; body comes from the archive
+		{Title: "Lost Article"},
+	}}
+	content := func(a storage.ArticleRecord) string {
+		if a.Title == "Go Channels" {
+			return channelsBody
+		}
+		return ""
+	}
+
+	match, ok := NearestArticle(Fingerprint("Channels in Go", channelsBody), history, content)
+	if !ok || match.Article.Title != "Go Channels" || match.Similarity < 0.8 {
+		t.Errorf("NearestArticle() = %+v, %v, want Go Channels", match, ok)
+	}
+
+	if _, ok := NearestArticle(Fingerprint("x", "y"), &storage.ArticleHistory{Articles: history.Articles[2:]}, content); ok {
+		t.Error("NearestArticle() should report no match when nothing can be compared")
+	}
+	if _, ok := NearestArticle(Fingerprint("x", "y"), nil, nil); ok {
+		t.Error("NearestArticle(nil history) should report no match")
+	}
+}
@@ -0,0 +1,60 @@
+package article
+
+import (
+	"regexp"
+	"strings"
+)
+
+// summaryLength is the maximum length of a summary in characters.
+const summaryLength = 200
+
+var (
+	markdownLink   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownMarkup = regexp.MustCompile("[*_`]+")
+)
+
+// Summarize returns the first prose paragraph of a Markdown article as plain
+// text, cut at a word boundary to at most 200 characters. Headings, code
+// blocks, lists and quotes are skipped.
+func Summarize(content string) string {
+	inCode := false
+	var paragraph []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ">") ||
+			strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "|") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+
+	text := strings.Join(paragraph, " ")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = markdownMarkup.ReplaceAllString(text, "")
+	if len([]rune(text)) <= summaryLength {
+		return text
+	}
+
+	runes := []rune(text)[:summaryLength]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > summaryLength/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
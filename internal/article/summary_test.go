@@ -0,0 +1,43 @@
+package article
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "skips title and code",
+			content: "# Go Channels\n\n```go\nch := make(chan int)\n```\n\nChannels connect **goroutines**.\nThey are typed.\n\nSecond paragraph.",
+			want:    "Channels connect goroutines. They are typed.",
+		},
+		{
+			name:    "strips links",
+			content: "See the [Go blog](https://go.dev/blog) and `context` docs.",
+			want:    "See the Go blog and context docs.",
+		},
+		{
+			name:    "only headings",
+			content: "# Title\n## Section",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.content); got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	long := strings.Repeat("word ", 100)
+	got := Summarize(long)
+	if !strings.HasSuffix(got, "word…") || len([]rune(got)) > summaryLength+1 {
+		t.Errorf("Summarize(long) = %q, want a cut at a word boundary", got)
+	}
+}
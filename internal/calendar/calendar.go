@@ -0,0 +1,317 @@
+// Package calendar reads the editorial calendar: topics, formats and themes
+// pinned to dates or ISO weeks, plus blackout dates when nothing is published.
+package calendar
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+const dateLayout = "2006-01-02"
+
+// Span is an inclusive range of calendar days. Days are stored as midnight UTC.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParseSpan parses a single date ("2025-11-03"), an ISO week ("2025-W45")
+// or an inclusive date range ("2025-12-24..2026-01-01").
+func ParseSpan(s string) (Span, error) {
+	s = strings.TrimSpace(s)
+	if from, to, ok := strings.Cut(s, ".."); ok {
+		start, err := parseDay(from)
+		if err != nil {
+			return Span{}, err
+		}
+		end, err := parseDay(to)
+		if err != nil {
+			return Span{}, err
+		}
+		if end.Before(start) {
+			return Span{}, fmt.Errorf("date range %q ends before it starts", s)
+		}
+		return Span{Start: start, End: end}, nil
+	}
+
+	if year, week, ok := strings.Cut(strings.ToUpper(s), "-W"); ok {
+		y, errY := strconv.Atoi(year)
+		w, errW := strconv.Atoi(week)
+		if errY != nil || errW != nil || w < 1 || w > 53 {
+			return Span{}, fmt.Errorf("invalid ISO week %q (expected YYYY-Www)", s)
+		}
+		start := isoWeekStart(y, w)
+		if wy, _ := start.ISOWeek(); wy != y {
+			return Span{}, fmt.Errorf("invalid ISO week %q: %d has no week %d", s, y, w)
+		}
+		return Span{Start: start, End: start.AddDate(0, 0, 6)}, nil
+	}
+
+	day, err := parseDay(s)
+	if err != nil {
+		return Span{}, err
+	}
+	return Span{Start: day, End: day}, nil
+}
+
+func parseDay(s string) (time.Time, error) {
+	day, err := time.Parse(dateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, YYYY-Www or YYYY-MM-DD..YYYY-MM-DD)", s)
+	}
+	return day, nil
+}
+
+// isoWeekStart returns the Monday of ISO week w in year y. January 4th is
+// always in week 1.
+func isoWeekStart(y, w int) time.Time {
+	jan4 := time.Date(y, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+	return jan4.AddDate(0, 0, -offset+(w-1)*7)
+}
+
+// Day returns the calendar day of t, in t's own location, as midnight UTC.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Contains reports whether the calendar day of t falls within the span.
+func (s Span) Contains(t time.Time) bool {
+	day := Day(t)
+	return !day.Before(s.Start) && !day.After(s.End)
+}
+
+// Days returns the number of days in the span.
+func (s Span) Days() int {
+	return int(s.End.Sub(s.Start).Hours()/24) + 1
+}
+
+func (s Span) String() string {
+	if s.Start.Equal(s.End) {
+		return s.Start.Format(dateLayout)
+	}
+	return s.Start.Format(dateLayout) + ".." + s.End.Format(dateLayout)
+}
+
+// Entry pins a topic, format or theme to a date or week.
+type Entry struct {
+	When   Span
+	Topic  string // Topic to write about; empty lets normal selection pick one
+	Format string // e.g. "tutorial", "deep dive", "listicle"
+	Theme  string // Editorial theme for the period
+	Note   string
+}
+
+// Blackout is a period when no article should be published.
+type Blackout struct {
+	When    Span
+	Reason  string
+	Holiday bool
+}
+
+// Calendar is a parsed editorial calendar.
+type Calendar struct {
+	Entries   []Entry
+	Blackouts []Blackout
+}
+
+// Load reads a calendar from a YAML (.yaml, .yml) or CSV (.csv) file.
+func Load(path string) (*Calendar, error) {
+	// #nosec G304 -- path is from config file, user-controlled
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseYAML(file)
+	case ".csv":
+		return ParseCSV(file)
+	default:
+		return nil, fmt.Errorf("unsupported calendar file %s (expected .yaml, .yml or .csv)", path)
+	}
+}
+
+// ParseYAML reads a calendar of the form:
+//
+//	entries:
+//	  - date: 2025-11-04
+//	    topic: "Go 1.26 Release Highlights"
+//	    format: tutorial
+//	  - date: 2025-W47
+//	    theme: Observability
+//	blackouts:
+//	  - date: 2025-12-24..2026-01-01
+//	    reason: Winter holidays
+//	    holiday: true
+func ParseYAML(r io.Reader) (*Calendar, error) {
+	var raw struct {
+		Entries []struct {
+			Date   string `yaml:"date"`
+			Topic  string `yaml:"topic"`
+			Format string `yaml:"format"`
+			Theme  string `yaml:"theme"`
+			Note   string `yaml:"note"`
+		} `yaml:"entries"`
+		Blackouts []struct {
+			Date    string `yaml:"date"`
+			Reason  string `yaml:"reason"`
+			Holiday bool   `yaml:"holiday"`
+		} `yaml:"blackouts"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+	}
+
+	cal := &Calendar{}
+	for i, e := range raw.Entries {
+		entry, err := newEntry(e.Date, e.Topic, e.Format, e.Theme, e.Note)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		cal.Entries = append(cal.Entries, entry)
+	}
+	for i, b := range raw.Blackouts {
+		when, err := ParseSpan(b.Date)
+		if err != nil {
+			return nil, fmt.Errorf("blackout %d: %w", i+1, err)
+		}
+		cal.Blackouts = append(cal.Blackouts, Blackout{When: when, Reason: strings.TrimSpace(b.Reason), Holiday: b.Holiday})
+	}
+	return cal, nil
+}
+
+// ParseCSV reads a calendar with a header row. The date column is required;
+// type, topic, format, theme and note are optional. Rows with type
+// "blackout" or "holiday" are blackouts and use note as the reason.
+//
+//	date,type,topic,format,theme,note
+//	2025-11-04,,Go 1.26 Release Highlights,tutorial,,
+//	2025-W47,,,,Observability,
+//	2025-12-25,holiday,,,,Christmas
+func ParseCSV(r io.Reader) (*Calendar, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+	}
+	if len(records) == 0 {
+		return &Calendar{}, nil
+	}
+
+	cols := make(map[string]int)
+	for i, col := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	if _, ok := cols["date"]; !ok {
+		return nil, fmt.Errorf("calendar CSV must have a 'date' column")
+	}
+	field := func(row []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	cal := &Calendar{}
+	for i, row := range records[1:] {
+		line := i + 2
+		date := field(row, "date")
+		switch kind := strings.ToLower(field(row, "type")); kind {
+		case "", "plan":
+			entry, err := newEntry(date, field(row, "topic"), field(row, "format"), field(row, "theme"), field(row, "note"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			cal.Entries = append(cal.Entries, entry)
+		case "blackout", "holiday":
+			when, err := ParseSpan(date)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			cal.Blackouts = append(cal.Blackouts, Blackout{When: when, Reason: field(row, "note"), Holiday: kind == "holiday"})
+		default:
+			return nil, fmt.Errorf("line %d: unknown type %q (expected plan, blackout or holiday)", line, kind)
+		}
+	}
+	return cal, nil
+}
+
+func newEntry(date, topic, format, theme, note string) (Entry, error) {
+	when, err := ParseSpan(date)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{
+		When:   when,
+		Topic:  strings.TrimSpace(topic),
+		Format: strings.TrimSpace(format),
+		Theme:  strings.TrimSpace(theme),
+		Note:   strings.TrimSpace(note),
+	}
+	if entry.Topic == "" && entry.Format == "" && entry.Theme == "" {
+		return Entry{}, fmt.Errorf("%s: entry needs a topic, format or theme", when)
+	}
+	return entry, nil
+}
+
+// BlackoutOn returns the blackout covering day, if any.
+func (c *Calendar) BlackoutOn(day time.Time) (Blackout, bool) {
+	for _, b := range c.Blackouts {
+		if b.When.Contains(day) {
+			return b, true
+		}
+	}
+	return Blackout{}, false
+}
+
+// PlanFor returns the calendar entry that applies on day. Entries for a
+// single date win over weekly and ranged entries. A pinned topic that was
+// already published within its span is considered done and skipped, so a
+// weekly pin produces one article rather than one per run.
+func (c *Calendar) PlanFor(day time.Time, history *storage.ArticleHistory) (Entry, bool) {
+	var candidates []Entry
+	for _, e := range c.Entries {
+		if e.When.Contains(day) && !published(e, history) {
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) == 0 {
+		return Entry{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].When.Days() < candidates[j].When.Days()
+	})
+	return candidates[0], true
+}
+
+func published(e Entry, history *storage.ArticleHistory) bool {
+	if e.Topic == "" || history == nil {
+		return false
+	}
+	for _, a := range history.Articles {
+		if strings.EqualFold(a.Topic, e.Topic) && e.When.Contains(a.PublishedAt) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseSpan(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{input: "2025-11-04", wantStart: "2025-11-04", wantEnd: "2025-11-04"},
+		{input: "2025-W47", wantStart: "2025-11-17", wantEnd: "2025-11-23"},
+		{input: "2026-w01", wantStart: "2025-12-29", wantEnd: "2026-01-04"},
+		{input: "2020-W53", wantStart: "2020-12-28", wantEnd: "2021-01-03"},
+		{input: "2025-12-24..2026-01-01", wantStart: "2025-12-24", wantEnd: "2026-01-01"},
+		{input: "2025-W53", wantErr: true},
+		{input: "2025-W00", wantErr: true},
+		{input: "2026-01-02..2025-12-24", wantErr: true},
+		{input: "next tuesday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			span, err := ParseSpan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSpan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := span.Start.Format(dateLayout); got != tt.wantStart {
+				t.Errorf("Start = %s, want %s", got, tt.wantStart)
+			}
+			if got := span.End.Format(dateLayout); got != tt.wantEnd {
+				t.Errorf("End = %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSpanContains_UsesLocalDay(t *testing.T) {
+	span, _ := ParseSpan("2025-11-04")
+	tz := time.FixedZone("UTC-8", -8*60*60)
+	// 2025-11-04 23:00 in UTC-8 is 2025-11-05 in UTC but still the 4th locally
+	if !span.Contains(time.Date(2025, 11, 4, 23, 0, 0, 0, tz)) {
+		t.Error("Contains() should use the calendar day in t's location")
+	}
+	if span.Contains(time.Date(2025, 11, 5, 0, 0, 0, 0, tz)) {
+		t.Error("Contains() should exclude the following day")
+	}
+}
+
+const testYAML = `
+entries:
+  - date: 2025-11-04
+    topic: "Go 1.26 Release Highlights"
+    format: tutorial
+  - date: 2025-W45
+    theme: Observability
+  - date: 2025-W46
+    topic: "Tracing with OpenTelemetry"
+blackouts:
+  - date: 2025-12-24..2026-01-01
+    reason: Winter holidays
+    holiday: true
+`
+
+func TestParseYAML(t *testing.T) {
+	cal, err := ParseYAML(strings.NewReader(testYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	if len(cal.Entries) != 3 || len(cal.Blackouts) != 1 {
+		t.Fatalf("ParseYAML() = %d entries, %d blackouts, want 3 and 1", len(cal.Entries), len(cal.Blackouts))
+	}
+	if e := cal.Entries[0]; e.Topic != "Go 1.26 Release Highlights" || e.Format != "tutorial" {
+		t.Errorf("entry = %+v", e)
+	}
+	if b := cal.Blackouts[0]; !b.Holiday || b.Reason != "Winter holidays" || b.When.Days() != 9 {
+		t.Errorf("blackout = %+v", b)
+	}
+
+	if _, err := ParseYAML(strings.NewReader("entries:\n  - date: 2025-11-04\n")); err == nil {
+		t.Error("ParseYAML() should reject entries without topic, format or theme")
+	}
+	if _, err := ParseYAML(strings.NewReader("")); err != nil {
+		t.Errorf("ParseYAML(empty) error = %v", err)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := `date,type,topic,format,theme,note
+# Q4 plan
+2025-11-04,,Go 1.26 Release Highlights,tutorial,,
+2025-W47,plan,,,Observability,
+2025-12-25,holiday,,,,Christmas
+`
+	cal, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	if len(cal.Entries) != 2 || len(cal.Blackouts) != 1 {
+		t.Fatalf("ParseCSV() = %d entries, %d blackouts, want 2 and 1", len(cal.Entries), len(cal.Blackouts))
+	}
+	if cal.Entries[1].Theme != "Observability" || cal.Blackouts[0].Reason != "Christmas" {
+		t.Errorf("calendar = %+v", cal)
+	}
+
+	_, err = ParseCSV(strings.NewReader("date,type\n2025-11-04,vacation\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ParseCSV() error = %v, want line 2 unknown type", err)
+	}
+	if _, err := ParseCSV(strings.NewReader("topic\nGo\n")); err == nil {
+		t.Error("ParseCSV() should require a date column")
+	}
+}
+
+func TestPlanFor(t *testing.T) {
+	cal, err := ParseYAML(strings.NewReader(testYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	history := &storage.ArticleHistory{}
+
+	// A dated entry wins over the week's theme
+	if e, ok := cal.PlanFor(day("2025-11-04"), history); !ok || e.Topic != "Go 1.26 Release Highlights" {
+		t.Errorf("PlanFor(2025-11-04) = %+v, %v", e, ok)
+	}
+	if e, ok := cal.PlanFor(day("2025-11-05"), history); !ok || e.Theme != "Observability" {
+		t.Errorf("PlanFor(2025-11-05) = %+v, %v", e, ok)
+	}
+	if _, ok := cal.PlanFor(day("2025-12-01"), history); ok {
+		t.Error("PlanFor() should find nothing outside planned dates")
+	}
+
+	// Once the weekly topic is published, the rest of the week is unplanned
+	if e, ok := cal.PlanFor(day("2025-11-12"), history); !ok || e.Topic != "Tracing with OpenTelemetry" {
+		t.Errorf("PlanFor(2025-11-12) = %+v, %v", e, ok)
+	}
+	history.Articles = append(history.Articles, storage.ArticleRecord{Topic: "Tracing with OpenTelemetry", PublishedAt: day("2025-11-10")})
+	if e, ok := cal.PlanFor(day("2025-11-12"), history); ok {
+		t.Errorf("PlanFor() = %+v, want nothing after the pinned topic was published", e)
+	}
+}
+
+func TestBlackoutOn(t *testing.T) {
+	cal, err := ParseYAML(strings.NewReader(testYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	if b, ok := cal.BlackoutOn(day("2026-01-01")); !ok || b.Reason != "Winter holidays" {
+		t.Errorf("BlackoutOn(2026-01-01) = %+v, %v", b, ok)
+	}
+	if _, ok := cal.BlackoutOn(day("2026-01-02")); ok {
+		t.Error("BlackoutOn(2026-01-02) should be false")
+	}
+}
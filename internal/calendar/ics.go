@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// Event is a single all-day entry in an exported iCalendar file.
+type Event struct {
+	UID         string
+	When        Span
+	Summary     string
+	Description string
+	URL         string
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+}
+
+// Events lists planned entries, blackouts and published articles as
+// calendar events, ordered by start date. cal may be nil.
+func Events(cal *Calendar, history *storage.ArticleHistory) []Event {
+	var events []Event
+
+	if cal != nil {
+		for _, e := range cal.Entries {
+			events = append(events, Event{
+				When:        e.When,
+				Summary:     plannedSummary(e),
+				Description: joinNonEmpty("\n", labelled("Format", e.Format), labelled("Theme", e.Theme), e.Note),
+				Status:      "TENTATIVE",
+			})
+		}
+		for _, b := range cal.Blackouts {
+			summary := "No publishing"
+			if b.Reason != "" {
+				summary += ": " + b.Reason
+			}
+			events = append(events, Event{When: b.When, Summary: summary, Status: "CANCELLED"})
+		}
+	}
+
+	if history != nil {
+		for _, a := range history.Articles {
+			day := Day(a.PublishedAt)
+			events = append(events, Event{
+				When:        Span{Start: day, End: day},
+				Summary:     "Published: " + a.Title,
+				Description: joinNonEmpty("\n", labelled("Topic", a.Topic), labelled("Tags", strings.Join(a.Tags, ", "))),
+				URL:         a.URL,
+				Status:      "CONFIRMED",
+			})
+		}
+	}
+
+	for i := range events {
+		events[i].UID = eventUID(events[i])
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].When.Start.Before(events[j].When.Start)
+	})
+	return events
+}
+
+func plannedSummary(e Entry) string {
+	switch {
+	case e.Topic != "":
+		return "Planned: " + e.Topic
+	case e.Theme != "":
+		return "Theme: " + e.Theme
+	default:
+		return "Format: " + e.Format
+	}
+}
+
+func labelled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + ": " + value
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// eventUID derives a stable UID so re-imports update events instead of
+// duplicating them.
+func eventUID(e Event) string {
+	sum := sha256.Sum256([]byte(e.When.String() + "|" + e.Summary + "|" + e.URL))
+	return hex.EncodeToString(sum[:12]) + "@autoblog-ai"
+}
+
+// WriteICS writes events as an RFC 5545 iCalendar file.
+func WriteICS(w io.Writer, events []Event, now time.Time) error {
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//autoblog-ai//Editorial Calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Editorial Calendar")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + e.When.Start.Format("20060102"))
+		// DTEND is exclusive for all-day events
+		line("DTEND;VALUE=DATE:" + e.When.End.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.URL != "" {
+			line("URL:" + e.URL)
+		}
+		if e.Status != "" {
+			line("STATUS:" + e.Status)
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return nil
+}
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldLine splits content lines longer than 75 octets, continuing them with
+// a leading space, without breaking UTF-8 sequences.
+func foldLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestEvents(t *testing.T) {
+	cal, err := ParseYAML(strings.NewReader(testYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Title: "Tracing, Spans; and You", Topic: "Tracing with OpenTelemetry", URL: "https://medium.com/p/1", PublishedAt: day("2025-11-10")},
+	}}
+
+	events := Events(cal, history)
+	if len(events) != 5 {
+		t.Fatalf("Events() = %d events, want 5", len(events))
+	}
+	if events[0].Summary != "Theme: Observability" {
+		t.Errorf("first event = %q, want the week 45 theme", events[0].Summary)
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range events {
+		if seen[e.UID] {
+			t.Errorf("duplicate UID %s", e.UID)
+		}
+		seen[e.UID] = true
+	}
+	if again := Events(cal, history); again[0].UID != events[0].UID {
+		t.Error("Events() UIDs should be stable across runs")
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	events := []Event{
+		{
+			UID:         "abc@autoblog-ai",
+			When:        Span{Start: day("2025-11-17"), End: day("2025-11-23")},
+			Summary:     "Published: Tracing, Spans; and You",
+			Description: "Topic: Tracing\nTags: go",
+			URL:         "https://medium.com/p/1",
+			Status:      "CONFIRMED",
+		},
+		{UID: "long@autoblog-ai", When: Span{Start: day("2025-12-01"), End: day("2025-12-01")}, Summary: strings.Repeat("é", 60)},
+	}
+
+	var b strings.Builder
+	if err := WriteICS(&b, events, time.Date(2025, 11, 1, 8, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20251101T083000Z\r\n",
+		"DTSTART;VALUE=DATE:20251117\r\n",
+		"DTEND;VALUE=DATE:20251124\r\n",
+		`SUMMARY:Published: Tracing\, Spans\; and You` + "\r\n",
+		`DESCRIPTION:Topic: Tracing\nTags: go` + "\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteICS() missing %q", want)
+		}
+	}
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", line)
+		}
+	}
+	if !strings.Contains(out, "\r\n é") {
+		t.Error("long lines should be folded with a leading space")
+	}
+}
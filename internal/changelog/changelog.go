@@ -0,0 +1,204 @@
+// Package changelog parses Keep a Changelog style release notes and git
+// history into structured releases.
+package changelog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Release is a single version entry in a changelog.
+type Release struct {
+	Version  string
+	Date     string
+	Sections []Section
+	Commits  []Commit
+}
+
+// Section groups changelog items under a heading such as "Added" or "Fixed".
+type Section struct {
+	Name  string
+	Items []string
+}
+
+// Commit is a git commit included in a release.
+type Commit struct {
+	Hash    string
+	Author  string
+	Subject string
+}
+
+var (
+	// versionHeading matches "## [1.2.0] - 2024-05-01", "## v1.2.0" and
+	// "# What's Changed in v1.2.0".
+	versionHeading = regexp.MustCompile(`^#{1,2}\s+(?:.*?\s)?\[?v?(\d+\.\d+\.\d+[0-9A-Za-z.+-]*)\]?(?:\s+[-–]\s+(\d{4}-\d{2}-\d{2}))?\s*$`)
+	// unreleasedHeading matches "## [Unreleased]".
+	unreleasedHeading = regexp.MustCompile(`(?i)^#{1,2}\s+\[?unreleased\]?\s*$`)
+	sectionHeading    = regexp.MustCompile(`^###\s+(.+?)\s*$`)
+	otherHeading      = regexp.MustCompile(`^#{1,6}\s+`)
+	listItem          = regexp.MustCompile(`^[-*+]\s+(.+)$`)
+)
+
+// ParseFile parses the changelog at path.
+func ParseFile(path string) ([]Release, error) {
+	// #nosec G304 -- path is provided by the user as the changelog location
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Parse(file)
+}
+
+// Parse reads releases in the order they appear, newest first by convention.
+// Items under non-version level-two headings (e.g. "## Installation") are ignored.
+func Parse(r io.Reader) ([]Release, error) {
+	var (
+		releases []Release
+		release  *Release
+		section  *Section
+	)
+
+	flushSection := func() {
+		if release != nil && section != nil && len(section.Items) > 0 {
+			release.Sections = append(release.Sections, *section)
+		}
+		section = nil
+	}
+	flushRelease := func() {
+		flushSection()
+		if release != nil {
+			releases = append(releases, *release)
+		}
+		release = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case unreleasedHeading.MatchString(line):
+			flushRelease()
+			release = &Release{Version: "Unreleased"}
+		case versionHeading.MatchString(line):
+			m := versionHeading.FindStringSubmatch(line)
+			flushRelease()
+			release = &Release{Version: m[1], Date: m[2]}
+		case sectionHeading.MatchString(line):
+			flushSection()
+			if release != nil {
+				section = &Section{Name: cleanSectionName(sectionHeading.FindStringSubmatch(line)[1])}
+			}
+		case otherHeading.MatchString(line):
+			flushSection()
+		case section != nil && listItem.MatchString(line):
+			section.Items = append(section.Items, listItem.FindStringSubmatch(line)[1])
+		case section != nil && line != "" && len(section.Items) > 0 && (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")):
+			// Continuation of a wrapped list item
+			last := len(section.Items) - 1
+			section.Items[last] += " " + line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flushRelease()
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases found in changelog")
+	}
+	return releases, nil
+}
+
+// cleanSectionName strips leading emoji and punctuation, so "🐛 Bug Fixes"
+// becomes "Bug Fixes".
+func cleanSectionName(name string) string {
+	return strings.TrimLeftFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Find returns the release with the given version, ignoring a leading "v".
+// An empty version selects the newest release that is not "Unreleased".
+func Find(releases []Release, version string) (*Release, error) {
+	version = strings.TrimPrefix(version, "v")
+	for i := range releases {
+		r := &releases[i]
+		if version == "" && r.Version != "Unreleased" {
+			return r, nil
+		}
+		if version != "" && strings.EqualFold(r.Version, version) {
+			return r, nil
+		}
+	}
+	if version == "" {
+		return nil, fmt.Errorf("changelog has no released versions")
+	}
+	return nil, fmt.Errorf("version %s not found in changelog", version)
+}
+
+// GitLog lists the commits in revRange (e.g. "v0.2.0..v0.2.1") of the git
+// repository at repoDir, oldest first, skipping merge commits. A range
+// starting with "-" is refused, as git would read it as an option.
+func GitLog(ctx context.Context, repoDir, revRange string) ([]Commit, error) {
+	if revRange == "" || strings.HasPrefix(revRange, "-") {
+		return nil, fmt.Errorf("invalid revision range %q", revRange)
+	}
+	// #nosec G204 -- arguments are passed directly to git, not through a shell
+	cmd := exec.CommandContext(ctx, "git", "-C", repoDir, "log", "--no-merges", "--reverse", "--format=%h%x1f%an%x1f%s", revRange, "--")
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log %s failed: %s", revRange, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git log %s failed: %w", revRange, err)
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.SplitN(line, "\x1f", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: parts[0], Author: parts[1], Subject: parts[2]})
+	}
+	return commits, nil
+}
+
+// Notes renders the release as structured Markdown release notes for use in prompts.
+func (r *Release) Notes() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Version: %s\n", r.Version))
+	if r.Date != "" {
+		b.WriteString(fmt.Sprintf("Release date: %s\n", r.Date))
+	}
+
+	for _, s := range r.Sections {
+		b.WriteString(fmt.Sprintf("\n%s:\n", s.Name))
+		for _, item := range s.Items {
+			b.WriteString(fmt.Sprintf("- %s\n", item))
+		}
+	}
+
+	if len(r.Commits) > 0 {
+		b.WriteString("\nCommits:\n")
+		for _, c := range r.Commits {
+			b.WriteString(fmt.Sprintf("- %s %s (%s)\n", c.Hash, c.Subject, c.Author))
+		}
+	}
+
+	return b.String()
+}
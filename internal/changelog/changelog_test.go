@@ -0,0 +1,188 @@
+package changelog
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+const keepAChangelog = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+### Added
+- Draft review queue
+
+## [1.1.0] - 2025-03-02
+
+### Added
+- Support for source documents
+  with citations.
+- Code maps for Go modules
+
+### Fixed
+* Retry on 529 overloaded errors
+
+## [1.0.0] - 2025-01-15
+
+### Added
+- Initial release
+
+[1.1.0]: https://example.com/compare/v1.0.0...v1.1.0
+`
+
+const generatedChangelog = `# Changelog
+
+---
+
+# What's Changed in v0.2.1
+
+## Version Bump: patch
+
+### 🐛 Bug Fixes
+- fix: release workflow permissions (9bbf6cc)
+- fix: remove .github from ignored paths (1385931)
+
+## Installation
+
+- **Linux (amd64)**: autoblog-ai-linux-amd64.tar.gz
+`
+
+func TestParse_KeepAChangelog(t *testing.T) {
+	releases, err := Parse(strings.NewReader(keepAChangelog))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(releases) != 3 {
+		t.Fatalf("Parse() releases = %d, want 3", len(releases))
+	}
+	if releases[0].Version != "Unreleased" {
+		t.Errorf("first release = %q, want Unreleased", releases[0].Version)
+	}
+
+	r := releases[1]
+	if r.Version != "1.1.0" || r.Date != "2025-03-02" {
+		t.Errorf("release = %s (%s), want 1.1.0 (2025-03-02)", r.Version, r.Date)
+	}
+	if len(r.Sections) != 2 || r.Sections[0].Name != "Added" || r.Sections[1].Name != "Fixed" {
+		t.Fatalf("sections = %+v", r.Sections)
+	}
+	if r.Sections[0].Items[0] != "Support for source documents with citations." {
+		t.Errorf("wrapped item = %q", r.Sections[0].Items[0])
+	}
+	if r.Sections[1].Items[0] != "Retry on 529 overloaded errors" {
+		t.Errorf("asterisk item = %q", r.Sections[1].Items[0])
+	}
+}
+
+func TestParse_GeneratedFormat(t *testing.T) {
+	releases, err := Parse(strings.NewReader(generatedChangelog))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(releases) != 1 || releases[0].Version != "0.2.1" {
+		t.Fatalf("releases = %+v, want v0.2.1", releases)
+	}
+	sections := releases[0].Sections
+	if len(sections) != 1 || sections[0].Name != "Bug Fixes" || len(sections[0].Items) != 2 {
+		t.Errorf("sections = %+v, want only Bug Fixes with 2 items", sections)
+	}
+}
+
+func TestParse_NoReleases(t *testing.T) {
+	if _, err := Parse(strings.NewReader("# Changelog\n\nNothing yet.\n")); err == nil {
+		t.Error("Parse() should error when no releases are present")
+	}
+}
+
+func TestFind(t *testing.T) {
+	releases, err := Parse(strings.NewReader(keepAChangelog))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "", want: "1.1.0"},
+		{version: "v1.0.0", want: "1.0.0"},
+		{version: "unreleased", want: "Unreleased"},
+		{version: "9.9.9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			r, err := Find(releases, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Find() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && r.Version != tt.want {
+				t.Errorf("Find() = %s, want %s", r.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotes(t *testing.T) {
+	r := &Release{
+		Version:  "1.1.0",
+		Date:     "2025-03-02",
+		Sections: []Section{{Name: "Added", Items: []string{"Code maps"}}},
+		Commits:  []Commit{{Hash: "abc1234", Author: "Dev", Subject: "feat: code maps"}},
+	}
+
+	notes := r.Notes()
+	for _, want := range []string{"Version: 1.1.0", "Release date: 2025-03-02", "Added:\n- Code maps", "- abc1234 feat: code maps (Dev)"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("Notes() missing %q in:\n%s", want, notes)
+		}
+	}
+}
+
+func TestGitLog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(),
+			"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q")
+	run("commit", "-q", "--allow-empty", "-m", "initial")
+	run("tag", "v1.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "feat: first")
+	run("commit", "-q", "--allow-empty", "-m", "fix: second")
+	run("tag", "v1.1.0")
+
+	commits, err := GitLog(t.Context(), dir, "v1.0.0..v1.1.0")
+	if err != nil {
+		t.Fatalf("GitLog() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "feat: first" || commits[1].Author != "Tester" {
+		t.Errorf("GitLog() = %+v, want two commits oldest first", commits)
+	}
+
+	if _, err := GitLog(t.Context(), dir, "v0.0.1..v1.1.0"); err == nil {
+		t.Error("GitLog() should error for an unknown revision")
+	}
+	for _, revRange := range []string{"", "--output=/tmp/x", "-p"} {
+		if _, err := GitLog(t.Context(), dir, revRange); err == nil || !strings.Contains(err.Error(), "invalid revision range") {
+			t.Errorf("GitLog(%q) error = %v, want it refused", revRange, err)
+		}
+	}
+}
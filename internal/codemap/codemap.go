@@ -0,0 +1,358 @@
+// Package codemap builds condensed summaries of Go source trees for use as
+// article generation context.
+package codemap
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Map is the exported API surface of a Go module.
+type Map struct {
+	Module   string
+	Packages []Package
+}
+
+// Package summarizes the exported API of a single Go package.
+type Package struct {
+	ImportPath string
+	Name       string
+	Doc        string
+	Symbols    []Symbol
+	Examples   []Example
+}
+
+// Symbol is an exported declaration: a type, function, method, constant or variable.
+type Symbol struct {
+	Name string
+	Decl string // Source form of the declaration without function bodies
+	Doc  string
+}
+
+// Example is a runnable example function from a package's tests.
+type Example struct {
+	Name   string
+	Code   string
+	Output string
+}
+
+// Build walks the Go module rooted at root and extracts package
+// documentation, exported declarations and example tests.
+func Build(root string) (*Map, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("source path is not a directory: %s", root)
+	}
+
+	m := &Map{Module: modulePath(root)}
+	if m.Module == "" {
+		m.Module = filepath.Base(filepath.Clean(root))
+	}
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if p != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		importPath := m.Module
+		if rel != "." {
+			importPath = path.Join(m.Module, filepath.ToSlash(rel))
+		}
+
+		pkg, err := buildPackage(p, importPath)
+		if err != nil {
+			return err
+		}
+		if pkg != nil {
+			m.Packages = append(m.Packages, *pkg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Packages) == 0 {
+		return nil, fmt.Errorf("no Go packages found under %s", root)
+	}
+	return m, nil
+}
+
+// buildPackage parses the Go files in dir. It returns nil when the directory
+// contains no non-test Go package.
+func buildPackage(dir, importPath string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, e.Name()), err)
+		}
+		if f.Name.Name == "main" || (strings.HasSuffix(e.Name(), "_test.go") && !hasExamples(f)) {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	dp, err := doc.NewFromFiles(fset, files, importPath)
+	if err != nil {
+		return nil, err
+	}
+	if dp.Name == "" || strings.HasSuffix(dp.Name, "_test") {
+		return nil, nil
+	}
+
+	pkg := &Package{
+		ImportPath: importPath,
+		Name:       dp.Name,
+		Doc:        strings.TrimSpace(dp.Doc),
+	}
+
+	addValues := func(values []*doc.Value) {
+		for _, v := range values {
+			pkg.Symbols = append(pkg.Symbols, Symbol{
+				Name: strings.Join(v.Names, ", "),
+				Decl: render(fset, v.Decl),
+				Doc:  dp.Synopsis(v.Doc),
+			})
+		}
+	}
+	addFuncs := func(funcs []*doc.Func) {
+		for _, f := range funcs {
+			name := f.Name
+			if f.Recv != "" {
+				name = strings.TrimPrefix(f.Recv, "*") + "." + f.Name
+			}
+			pkg.Symbols = append(pkg.Symbols, Symbol{
+				Name: name,
+				Decl: render(fset, &ast.FuncDecl{Recv: f.Decl.Recv, Name: f.Decl.Name, Type: f.Decl.Type}),
+				Doc:  dp.Synopsis(f.Doc),
+			})
+		}
+	}
+	addExamples := func(examples []*doc.Example) {
+		for _, ex := range examples {
+			pkg.Examples = append(pkg.Examples, Example{
+				Name:   "Example" + ex.Name,
+				Code:   render(fset, ex.Code),
+				Output: strings.TrimSpace(ex.Output),
+			})
+		}
+	}
+
+	addValues(dp.Consts)
+	addValues(dp.Vars)
+	for _, t := range dp.Types {
+		pkg.Symbols = append(pkg.Symbols, Symbol{
+			Name: t.Name,
+			Decl: render(fset, t.Decl),
+			Doc:  dp.Synopsis(t.Doc),
+		})
+		addValues(t.Consts)
+		addValues(t.Vars)
+		addFuncs(t.Funcs)
+		addFuncs(t.Methods)
+		addExamples(t.Examples)
+		for _, f := range t.Funcs {
+			addExamples(f.Examples)
+		}
+		for _, m := range t.Methods {
+			addExamples(m.Examples)
+		}
+	}
+	addFuncs(dp.Funcs)
+	addExamples(dp.Examples)
+	for _, f := range dp.Funcs {
+		addExamples(f.Examples)
+	}
+
+	if pkg.Doc == "" && len(pkg.Symbols) == 0 {
+		return nil, nil
+	}
+	return pkg, nil
+}
+
+// hasExamples reports whether a test file declares any Example functions.
+func hasExamples(f *ast.File) bool {
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && strings.HasPrefix(fn.Name.Name, "Example") {
+			return true
+		}
+	}
+	return false
+}
+
+// render prints an AST node back to Go source.
+func render(fset *token.FileSet, node any) string {
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := cfg.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// modulePath reads the module path from root/go.mod, if present.
+func modulePath(root string) string {
+	// #nosec G304 -- root is the user-provided source directory
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// EstimateTokens approximates the number of model tokens in s, using the
+// common heuristic of four characters per token.
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// section is one renderable piece of a package summary. Lower priorities are
+// kept first when the token budget is tight.
+type section struct {
+	pkg      int
+	priority int
+	order    int
+	text     string
+}
+
+const (
+	priorityHeader = iota
+	prioritySymbol
+	priorityExample
+	priorityPackageDoc
+)
+
+// Render formats the map as Markdown, keeping it within maxTokens. Package
+// headers are kept first, then exported declarations, then examples, then
+// full package documentation. A maxTokens of zero disables the budget.
+func (m *Map) Render(maxTokens int) string {
+	var sections []section
+	for i, pkg := range m.Packages {
+		header := fmt.Sprintf("### package %s (%s)\n", pkg.Name, pkg.ImportPath)
+		if synopsis := firstSentence(pkg.Doc); synopsis != "" {
+			header += synopsis + "\n"
+		}
+		sections = append(sections, section{pkg: i, priority: priorityHeader, text: header})
+
+		for j, sym := range pkg.Symbols {
+			text := "```go\n" + sym.Decl + "\n```\n"
+			if sym.Doc != "" {
+				text = sym.Doc + "\n" + text
+			}
+			sections = append(sections, section{pkg: i, priority: prioritySymbol, order: j, text: text})
+		}
+		for j, ex := range pkg.Examples {
+			text := fmt.Sprintf("%s:\n```go\n%s\n```\n", ex.Name, ex.Code)
+			if ex.Output != "" {
+				text += "Output:\n```\n" + ex.Output + "\n```\n"
+			}
+			sections = append(sections, section{pkg: i, priority: priorityExample, order: j, text: text})
+		}
+		if pkg.Doc != "" && pkg.Doc != firstSentence(pkg.Doc) {
+			sections = append(sections, section{pkg: i, priority: priorityPackageDoc, text: pkg.Doc + "\n"})
+		}
+	}
+
+	header := fmt.Sprintf("## Code map for module %s\n\n", m.Module)
+	used := EstimateTokens(header)
+
+	byPriority := make([]section, len(sections))
+	copy(byPriority, sections)
+	sort.SliceStable(byPriority, func(a, b int) bool {
+		return byPriority[a].priority < byPriority[b].priority
+	})
+
+	kept := make([]section, 0, len(sections))
+	omitted := 0
+	for _, s := range byPriority {
+		cost := EstimateTokens(s.text)
+		if maxTokens > 0 && used+cost > maxTokens {
+			omitted++
+			continue
+		}
+		used += cost
+		kept = append(kept, s)
+	}
+
+	sort.SliceStable(kept, func(a, b int) bool {
+		if kept[a].pkg != kept[b].pkg {
+			return kept[a].pkg < kept[b].pkg
+		}
+		if kept[a].priority != kept[b].priority {
+			return kept[a].priority < kept[b].priority
+		}
+		return kept[a].order < kept[b].order
+	})
+
+	var b strings.Builder
+	b.WriteString(header)
+	for i, s := range kept {
+		if i > 0 && s.priority == priorityHeader {
+			b.WriteString("\n")
+		}
+		b.WriteString(s.text)
+	}
+	if omitted > 0 {
+		b.WriteString(fmt.Sprintf("\n(%d further declarations, examples or docs omitted to fit the token budget)\n", omitted))
+	}
+	return b.String()
+}
+
+// firstSentence returns the first sentence of a doc comment.
+func firstSentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if i := strings.Index(text, ". "); i >= 0 {
+		return text[:i+1]
+	}
+	return text
+}
@@ -0,0 +1,175 @@
+package codemap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	// #nosec G301 -- test directory permissions are acceptable
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func newTestModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/ratelimit\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(root, "limiter.go"), `// Package ratelimit implements a token bucket rate limiter. It is safe for
+// concurrent use.
+package ratelimit
+
+import "time"
+
+// Limiter hands out tokens at a fixed rate.
+type Limiter struct {
+	Rate  int
+	burst int
+}
+
+// New creates a Limiter allowing rate events per second.
+func New(rate int) *Limiter {
+	return &Limiter{Rate: rate}
+}
+
+// Allow reports whether an event may happen now.
+func (l *Limiter) Allow() bool {
+	return l.burst > 0
+}
+
+// Wait blocks until an event may happen.
+func (l *Limiter) Wait(timeout time.Duration) error {
+	return nil
+}
+
+func unexported() {}
+`)
+	writeFile(t, filepath.Join(root, "example_test.go"), `package ratelimit_test
+
+import (
+	"fmt"
+
+	"example.com/ratelimit"
+)
+
+func ExampleNew() {
+	l := ratelimit.New(10)
+	fmt.Println(l.Rate)
+	// Output: 10
+}
+`)
+	writeFile(t, filepath.Join(root, "internal", "clock", "clock.go"), `// Package clock abstracts time.
+package clock
+
+// Now returns the current Unix time.
+func Now() int64 { return 0 }
+`)
+	writeFile(t, filepath.Join(root, "cmd", "tool", "main.go"), `package main
+
+func main() {}
+`)
+	writeFile(t, filepath.Join(root, "testdata", "bad.go"), `this is not go`)
+
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	m, err := Build(newTestModule(t))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if m.Module != "example.com/ratelimit" {
+		t.Errorf("Module = %q, want example.com/ratelimit", m.Module)
+	}
+	if len(m.Packages) != 2 {
+		t.Fatalf("Packages = %d, want 2 (main and testdata skipped)", len(m.Packages))
+	}
+
+	pkg := m.Packages[0]
+	if pkg.ImportPath != "example.com/ratelimit" || pkg.Name != "ratelimit" {
+		t.Errorf("first package = %s (%s)", pkg.Name, pkg.ImportPath)
+	}
+	if m.Packages[1].ImportPath != "example.com/ratelimit/internal/clock" {
+		t.Errorf("second package import path = %q", m.Packages[1].ImportPath)
+	}
+
+	names := make(map[string]Symbol)
+	for _, sym := range pkg.Symbols {
+		names[sym.Name] = sym
+	}
+	for _, want := range []string{"Limiter", "New", "Limiter.Allow", "Limiter.Wait"} {
+		if _, ok := names[want]; !ok {
+			t.Errorf("missing symbol %q", want)
+		}
+	}
+	if _, ok := names["unexported"]; ok {
+		t.Error("unexported functions should not be included")
+	}
+	if strings.Contains(names["Limiter"].Decl, "burst") {
+		t.Error("unexported struct fields should be filtered")
+	}
+	if strings.Contains(names["New"].Decl, "return") {
+		t.Error("function declarations should not include bodies")
+	}
+	if names["New"].Doc != "New creates a Limiter allowing rate events per second." {
+		t.Errorf("New doc = %q", names["New"].Doc)
+	}
+
+	if len(pkg.Examples) != 1 || pkg.Examples[0].Name != "ExampleNew" || pkg.Examples[0].Output != "10" {
+		t.Errorf("Examples = %+v, want ExampleNew with output", pkg.Examples)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	if _, err := Build(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Build() should error for a missing directory")
+	}
+	if _, err := Build(t.TempDir()); err == nil {
+		t.Error("Build() should error when no packages are found")
+	}
+}
+
+func TestRender(t *testing.T) {
+	m, err := Build(newTestModule(t))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	full := m.Render(0)
+	for _, want := range []string{
+		"## Code map for module example.com/ratelimit",
+		"### package ratelimit (example.com/ratelimit)",
+		"func New(rate int) *Limiter",
+		"func (l *Limiter) Wait(timeout time.Duration) error",
+		"ExampleNew:",
+		"### package clock",
+	} {
+		if !strings.Contains(full, want) {
+			t.Errorf("Render() missing %q in:\n%s", want, full)
+		}
+	}
+	if strings.Contains(full, "omitted") {
+		t.Error("unbounded Render() should not omit anything")
+	}
+
+	budget := 60
+	small := m.Render(budget)
+	if EstimateTokens(small) > budget+30 {
+		t.Errorf("Render(%d) produced ~%d tokens", budget, EstimateTokens(small))
+	}
+	if !strings.Contains(small, "### package ratelimit") || !strings.Contains(small, "### package clock") {
+		t.Error("package headers should be kept before declarations")
+	}
+	if !strings.Contains(small, "omitted to fit the token budget") {
+		t.Error("Render() should report omitted sections")
+	}
+}
@@ -2,11 +2,13 @@
 package config
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,13 +18,30 @@ import (
 
 // Config represents the main application configuration.
 type Config struct {
-	APIKeys        APIKeysConfig `yaml:"api_keys"`
-	AI             AIConfig      `yaml:"ai"`
-	Topics         []TopicConfig `yaml:"topics"`
-	Style          StyleConfig   `yaml:"style"`
-	TopicsFile     string        `yaml:"topics_file"`     // Optional: Path to CSV file
-	PromptTemplate string        `yaml:"prompt_template"` // Optional: Path to prompt template
-	SystemPrompt   string        `yaml:"system_prompt"`   // Optional: Path to system prompt
+	APIKeys        APIKeysConfig         `yaml:"api_keys"`
+	AI             AIConfig              `yaml:"ai"`
+	Topics         []TopicConfig         `yaml:"topics"`
+	Categories     []CategoryConfig      `yaml:"categories"`
+	Selection      SelectionConfig       `yaml:"selection"`
+	Duplicates     DuplicateConfig       `yaml:"duplicates"`
+	Review         ReviewConfig          `yaml:"review"`
+	Storage        StorageConfig         `yaml:"storage"`
+	Pricing        map[string]ModelPrice `yaml:"pricing"` // Keyed by model name prefix
+	Style          StyleConfig           `yaml:"style"`
+	TopicsFile     TopicFiles            `yaml:"topics_file"`     // Optional: topics files (CSV, YAML or JSON), a path or a list of paths and globs
+	StrictTopics   bool                  `yaml:"strict_topics"`   // Fail on any problem in a topics file instead of skipping rows
+	PromptTemplate string                `yaml:"prompt_template"` // Optional: Path to prompt template
+	SystemPrompt   string                `yaml:"system_prompt"`   // Optional: Path to system prompt
+	ReleasePrompt  string                `yaml:"release_prompt"`  // Optional: Path to release announcement template
+	CalendarFile   string                `yaml:"calendar_file"`   // Optional: Path to editorial calendar (YAML or CSV)
+
+	// TopicsPrecedence decides which definition wins when a topic is defined
+	// both inline and in a topics file: "files" (default) or "inline".
+	TopicsPrecedence string `yaml:"topics_precedence"`
+
+	// TopicConflicts lists topics defined differently in more than one
+	// source, filled in by Load.
+	TopicConflicts []TopicConflict `yaml:"-"`
 }
 
 // APIKeysConfig contains API credentials for external services.
@@ -41,18 +60,127 @@ type AIConfig struct {
 
 // TopicConfig defines a content topic with associated metadata.
 type TopicConfig struct {
-	Name        string   `yaml:"name"`
-	Description string   `yaml:"description"`
-	Keywords    []string `yaml:"keywords"`
-	Weight      int      `yaml:"weight"` // Higher weight = more likely to be selected
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Keywords    []string `yaml:"keywords" json:"keywords"`
+	Weight      int      `yaml:"weight" json:"weight"`                         // Higher weight = more likely to be selected
+	Category    string   `yaml:"category,omitempty" json:"category,omitempty"` // Optional: category the topic belongs to, e.g. "Go" or "AI"
+	Sources     []string `yaml:"sources,omitempty" json:"sources,omitempty"`   // Optional: PDF/Markdown/text files attached as source material
+	Enabled     *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"`   // Optional: false excludes the topic from selection
+
+	// Optional per-topic overrides of the style settings
+	Tone           string `yaml:"tone,omitempty" json:"tone,omitempty"`
+	Length         string `yaml:"length,omitempty" json:"length,omitempty"`
+	TargetAudience string `yaml:"target_audience,omitempty" json:"target_audience,omitempty"`
+
+	// Source is the file the topic was loaded from, set by Load
+	Source string `yaml:"-" json:"-"`
 }
 
+// IsEnabled reports whether the topic takes part in selection. Topics are
+// enabled unless explicitly disabled.
+func (t TopicConfig) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// CategoryConfig sets the selection weight and publishing quota of a topic
+// category. Topics name their category; categories without an entry here
+// are weighted by the sum of their topic weights and have no quota.
+type CategoryConfig struct {
+	Name       string `yaml:"name"`
+	Weight     int    `yaml:"weight"`      // Relative weight when choosing a category; 0 uses the sum of its topic weights
+	MaxPosts   int    `yaml:"max_posts"`   // Rolling quota: at most this many posts per period (0 = unlimited)
+	PeriodDays int    `yaml:"period_days"` // Quota window in days (default 30)
+}
+
+// SelectionConfig controls how a topic is chosen when none is given on the
+// command line.
+type SelectionConfig struct {
+	Strategy     string `yaml:"strategy"`      // weighted_random (default), cooldown, least_recent, round_robin, staleness, seeded
+	CooldownDays int    `yaml:"cooldown_days"` // cooldown: days before a topic may be picked again
+	Seed         int64  `yaml:"seed"`          // seeded: base seed for reproducible selection
+}
+
+// Values of storage.backend.
+const (
+	StorageJSON   = "json"   // A single JSON file
+	StorageSQLite = "sqlite" // An SQLite database with indexed queries
+	StorageS3     = "s3"     // An S3-compatible object store
+	StorageGit    = "git"    // A JSON file committed to its git repository
+)
+
+// StorageConfig selects where the article history is kept.
+type StorageConfig struct {
+	Backend string    `yaml:"backend"` // json (default), sqlite, s3 or git; the STORAGE_BACKEND env var overrides it
+	Path    string    `yaml:"path"`    // History file (default articles.json, or articles.db for sqlite); object key under s3.prefix for s3
+	S3      S3Config  `yaml:"s3"`
+	Git     GitConfig `yaml:"git"`
+}
+
+// GitConfig controls the git storage backend, which commits the history
+// file to the git repository it is in.
+type GitConfig struct {
+	Paths       []string `yaml:"paths"`        // Also committed with each article (default archive and generated)
+	Remote      string   `yaml:"remote"`       // Pushed to after each commit when set, e.g. "origin"
+	Branch      string   `yaml:"branch"`       // Remote branch to push to (default: the current branch)
+	AuthorName  string   `yaml:"author_name"`  // Commit author (default: from git config)
+	AuthorEmail string   `yaml:"author_email"` // Commit author email (default: from git config)
+}
+
+// S3Config locates the bucket of the s3 storage backend. Credentials are
+// usually taken from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN, which override the values here.
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint"`   // Default https://s3.<region>.amazonaws.com
+	Region          string `yaml:"region"`     // Default us-east-1
+	Bucket          string `yaml:"bucket"`     // Required
+	Prefix          string `yaml:"prefix"`     // Prepended to every object key, e.g. "autoblog/"
+	PathStyle       bool   `yaml:"path_style"` // Needed by MinIO and most self-hosted stores
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	SessionToken    string `yaml:"session_token"`
+}
+
+// ModelPrice is what a model costs in USD per million tokens.
+type ModelPrice struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// Values of duplicates.action.
+const (
+	DuplicateRegenerate = "regenerate" // Generate a new draft with a different angle
+	DuplicateReject     = "reject"     // Stop without publishing
+	DuplicateOff        = "off"        // Skip the check
+)
+
+// DuplicateConfig controls the near-duplicate check of new drafts against
+// published articles.
+type DuplicateConfig struct {
+	Threshold   float64 `yaml:"threshold"`    // Similarity (0-1) from which a draft counts as a near duplicate (default 0.5)
+	Action      string  `yaml:"action"`       // regenerate (default), reject or off
+	MaxAttempts int     `yaml:"max_attempts"` // regenerate: drafts to try before giving up (default 3)
+}
+
+// ReviewConfig controls whether generated articles wait for a reviewer.
+type ReviewConfig struct {
+	Required bool `yaml:"required"` // Store articles as pending drafts; publish approved ones with "publish --approved"
+}
+
+// KeywordsAll as style.keywords_per_article makes every article cover all
+// of its topic's keywords.
+const KeywordsAll = -1
+
 // StyleConfig defines the writing style and format preferences.
 type StyleConfig struct {
 	Tone           string `yaml:"tone"`            // e.g., "professional", "casual", "technical"
 	Length         string `yaml:"length"`          // e.g., "short", "medium", "long"
 	TargetAudience string `yaml:"target_audience"` // e.g., "beginners", "intermediate", "advanced"
 	IncludeCode    bool   `yaml:"include_code"`    // Whether to include code examples
+	// KeywordsPerArticle is how many of a topic's keywords each article
+	// focuses on, rotating through less-covered keywords first. 0 defaults
+	// to 3; KeywordsAll (-1) uses every keyword.
+	KeywordsPerArticle int `yaml:"keywords_per_article"`
 }
 
 // Load reads and parses a configuration file from the specified path.
@@ -83,6 +211,49 @@ func Load(path string) (*Config, error) {
 		config.AI.TimeoutSeconds = 120
 	}
 
+	// Set defaults for topic selection
+	if config.Selection.Strategy == "" {
+		config.Selection.Strategy = "weighted_random"
+	}
+	if config.Selection.Strategy == "cooldown" && config.Selection.CooldownDays == 0 {
+		config.Selection.CooldownDays = 21
+	}
+
+	// Set defaults for article history storage
+	if backend := os.Getenv("STORAGE_BACKEND"); backend != "" {
+		config.Storage.Backend = backend
+	}
+	if config.Storage.Backend == "" {
+		config.Storage.Backend = StorageJSON
+	}
+	if config.Storage.Path == "" && config.Storage.Backend == StorageSQLite {
+		config.Storage.Path = "articles.db"
+	}
+	if config.Storage.Path == "" {
+		config.Storage.Path = "articles.json"
+	}
+	if config.Storage.Backend == StorageGit && config.Storage.Git.Paths == nil {
+		config.Storage.Git.Paths = []string{"archive", "generated"}
+	}
+
+	// Set defaults for near-duplicate detection
+	if config.Duplicates.Threshold == 0 {
+		config.Duplicates.Threshold = 0.5
+	}
+	if config.Duplicates.Action == "" {
+		config.Duplicates.Action = DuplicateRegenerate
+	}
+	if config.Duplicates.MaxAttempts == 0 {
+		config.Duplicates.MaxAttempts = 3
+	}
+
+	// Set defaults for category quotas
+	for i := range config.Categories {
+		if config.Categories[i].MaxPosts > 0 && config.Categories[i].PeriodDays == 0 {
+			config.Categories[i].PeriodDays = 30
+		}
+	}
+
 	// Set defaults for style
 	if config.Style.Tone == "" {
 		config.Style.Tone = "professional"
@@ -93,6 +264,9 @@ func Load(path string) (*Config, error) {
 	if config.Style.TargetAudience == "" {
 		config.Style.TargetAudience = "intermediate"
 	}
+	if config.Style.KeywordsPerArticle == 0 {
+		config.Style.KeywordsPerArticle = 3
+	}
 
 	// Set defaults for file paths
 	if config.PromptTemplate == "" {
@@ -101,17 +275,17 @@ func Load(path string) (*Config, error) {
 	if config.SystemPrompt == "" {
 		config.SystemPrompt = "templates/system-prompt.md"
 	}
+	if config.ReleasePrompt == "" {
+		config.ReleasePrompt = "templates/release-prompt.md"
+	}
 
-	// If topics file is specified, load from CSV
-	if config.TopicsFile != "" {
-		csvTopics, err := loadTopicsFromCSV(config.TopicsFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load topics from CSV: %w", err)
-		}
-		// Replace or append topics from CSV
-		if len(csvTopics) > 0 {
-			config.Topics = csvTopics
-		}
+	if config.TopicsPrecedence == "" {
+		config.TopicsPrecedence = TopicsPrecedenceFiles
+	}
+
+	// Merge topics files with the inline topics
+	if err := config.mergeTopicSources(path); err != nil {
+		return nil, err
 	}
 
 	// If no topics loaded, use default
@@ -126,6 +300,15 @@ func Load(path string) (*Config, error) {
 	if mediumToken := os.Getenv("MEDIUM_TOKEN"); mediumToken != "" {
 		config.APIKeys.Medium = mediumToken
 	}
+	if accessKey := os.Getenv("AWS_ACCESS_KEY_ID"); accessKey != "" {
+		config.Storage.S3.AccessKeyID = accessKey
+	}
+	if secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY"); secretKey != "" {
+		config.Storage.S3.SecretAccessKey = secretKey
+	}
+	if sessionToken := os.Getenv("AWS_SESSION_TOKEN"); sessionToken != "" {
+		config.Storage.S3.SessionToken = sessionToken
+	}
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -151,6 +334,56 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("ai.model cannot be empty")
 	}
 
+	if c.Style.KeywordsPerArticle < KeywordsAll {
+		return fmt.Errorf("style.keywords_per_article must be %d (all keywords) or more, got %d", KeywordsAll, c.Style.KeywordsPerArticle)
+	}
+
+	if c.Selection.CooldownDays < 0 {
+		return fmt.Errorf("selection.cooldown_days cannot be negative, got %d", c.Selection.CooldownDays)
+	}
+
+	if c.Duplicates.Threshold < 0 || c.Duplicates.Threshold > 1 {
+		return fmt.Errorf("duplicates.threshold must be between 0 and 1, got %v", c.Duplicates.Threshold)
+	}
+	switch c.Duplicates.Action {
+	case "", DuplicateRegenerate, DuplicateReject, DuplicateOff:
+	default:
+		return fmt.Errorf("duplicates.action must be regenerate, reject or off, got %q", c.Duplicates.Action)
+	}
+	if c.Duplicates.MaxAttempts < 0 {
+		return fmt.Errorf("duplicates.max_attempts cannot be negative, got %d", c.Duplicates.MaxAttempts)
+	}
+
+	switch c.Storage.Backend {
+	case "", StorageJSON, StorageSQLite, StorageGit:
+	case StorageS3:
+		if c.Storage.S3.Bucket == "" {
+			return fmt.Errorf("storage.s3.bucket is required for the s3 backend")
+		}
+	default:
+		return fmt.Errorf("storage.backend must be json, sqlite, s3 or git, got %q", c.Storage.Backend)
+	}
+
+	for model, price := range c.Pricing {
+		if price.Input < 0 || price.Output < 0 {
+			return fmt.Errorf("pricing for %s cannot be negative", model)
+		}
+	}
+
+	seenCategories := make(map[string]bool, len(c.Categories))
+	for i, category := range c.Categories {
+		if category.Name == "" {
+			return fmt.Errorf("category %d has empty name", i)
+		}
+		if seenCategories[category.Name] {
+			return fmt.Errorf("category %q is defined more than once", category.Name)
+		}
+		seenCategories[category.Name] = true
+		if category.Weight < 0 || category.MaxPosts < 0 || category.PeriodDays < 0 {
+			return fmt.Errorf("category %q has a negative weight, max_posts or period_days", category.Name)
+		}
+	}
+
 	// Validate file paths exist
 	if _, err := os.Stat(c.PromptTemplate); err != nil {
 		return fmt.Errorf("prompt_template file not found: %s", c.PromptTemplate)
@@ -158,9 +391,17 @@ func (c *Config) Validate() error {
 	if _, err := os.Stat(c.SystemPrompt); err != nil {
 		return fmt.Errorf("system_prompt file not found: %s", c.SystemPrompt)
 	}
-	if c.TopicsFile != "" {
-		if _, err := os.Stat(c.TopicsFile); err != nil {
-			return fmt.Errorf("topics_file not found: %s", c.TopicsFile)
+	if _, err := c.TopicsFile.Expand(); err != nil {
+		return err
+	}
+	switch c.TopicsPrecedence {
+	case "", TopicsPrecedenceFiles, TopicsPrecedenceInline:
+	default:
+		return fmt.Errorf("topics_precedence must be %q or %q, got %q", TopicsPrecedenceFiles, TopicsPrecedenceInline, c.TopicsPrecedence)
+	}
+	if c.CalendarFile != "" {
+		if _, err := os.Stat(c.CalendarFile); err != nil {
+			return fmt.Errorf("calendar_file not found: %s", c.CalendarFile)
 		}
 	}
 
@@ -196,99 +437,16 @@ func (c *Config) GetMediumToken() string {
 	return c.APIKeys.Medium
 }
 
-func loadTopicsFromCSV(path string) ([]TopicConfig, error) {
-	// #nosec G304 -- path is from config file, user-controlled
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, err
-	}
-
-	if len(records) < 2 {
-		return nil, fmt.Errorf("CSV file must have header and at least one data row")
-	}
-
-	// Parse header to find column indices
-	header := records[0]
-	nameIdx, descIdx, keywordsIdx, weightIdx := -1, -1, -1, -1
-	for i, col := range header {
-		switch strings.ToLower(strings.TrimSpace(col)) {
-		case "name":
-			nameIdx = i
-		case "description":
-			descIdx = i
-		case "keywords":
-			keywordsIdx = i
-		case "weight":
-			weightIdx = i
-		}
-	}
-
-	if nameIdx == -1 {
-		return nil, fmt.Errorf("CSV must have 'name' column")
-	}
-
-	topics := make([]TopicConfig, 0, len(records)-1)
-	for i, record := range records[1:] {
-		if len(record) <= nameIdx {
-			continue
-		}
-
-		topic := TopicConfig{
-			Name:   strings.TrimSpace(record[nameIdx]),
-			Weight: 1, // Default weight
-		}
-
-		if descIdx != -1 && len(record) > descIdx {
-			topic.Description = strings.TrimSpace(record[descIdx])
-		}
-
-		if keywordsIdx != -1 && len(record) > keywordsIdx {
-			keywordsStr := strings.TrimSpace(record[keywordsIdx])
-			if keywordsStr != "" {
-				keywords := strings.Split(keywordsStr, ",")
-				for _, kw := range keywords {
-					if kw = strings.TrimSpace(kw); kw != "" {
-						topic.Keywords = append(topic.Keywords, kw)
-					}
-				}
-			}
-		}
-
-		if weightIdx != -1 && len(record) > weightIdx {
-			if weight, err := strconv.Atoi(strings.TrimSpace(record[weightIdx])); err == nil {
-				topic.Weight = weight
-			}
-		}
-
-		if topic.Name == "" {
-			fmt.Printf("Warning: Skipping row %d with empty name\n", i+2)
-			continue
-		}
-
-		topics = append(topics, topic)
-	}
-
-	return topics, nil
-}
-
 // SelectRandomTopic chooses a random topic based on weights.
 func (c *Config) SelectRandomTopic() string {
-	if len(c.Topics) == 0 {
+	topics := c.EnabledTopics()
+	if len(topics) == 0 {
 		return "Software Engineering Best Practices"
 	}
 
 	// Weighted random selection
 	totalWeight := 0
-	for _, topic := range c.Topics {
+	for _, topic := range topics {
 		weight := topic.Weight
 		if weight <= 0 {
 			weight = 1
@@ -301,7 +459,7 @@ func (c *Config) SelectRandomTopic() string {
 	random := r.Intn(totalWeight)
 
 	current := 0
-	for _, topic := range c.Topics {
+	for _, topic := range topics {
 		weight := topic.Weight
 		if weight <= 0 {
 			weight = 1
@@ -312,7 +470,37 @@ func (c *Config) SelectRandomTopic() string {
 		}
 	}
 
-	return c.Topics[0].Name
+	return topics[0].Name
+}
+
+// EnabledTopics returns the topics that take part in selection.
+func (c *Config) EnabledTopics() []TopicConfig {
+	enabled := make([]TopicConfig, 0, len(c.Topics))
+	for _, t := range c.Topics {
+		if t.IsEnabled() {
+			enabled = append(enabled, t)
+		}
+	}
+	return enabled
+}
+
+// StyleFor returns the style settings for a topic, applying its overrides.
+// topic may be nil.
+func (c *Config) StyleFor(topic *TopicConfig) StyleConfig {
+	style := c.Style
+	if topic == nil {
+		return style
+	}
+	if topic.Tone != "" {
+		style.Tone = topic.Tone
+	}
+	if topic.Length != "" {
+		style.Length = topic.Length
+	}
+	if topic.TargetAudience != "" {
+		style.TargetAudience = topic.TargetAudience
+	}
+	return style
 }
 
 // GetTopicDetails returns the configuration for a specific topic by name.
@@ -345,6 +533,11 @@ func (c *Config) GetSystemPromptPath() string {
 	return c.SystemPrompt
 }
 
+// GetReleasePromptPath returns the path to the release announcement template.
+func (c *Config) GetReleasePromptPath() string {
+	return c.ReleasePrompt
+}
+
 func getDefaultTopics() []TopicConfig {
 	return []TopicConfig{
 		{
@@ -356,7 +549,9 @@ func getDefaultTopics() []TopicConfig {
 	}
 }
 
-// ExportTopicsToCSV exports current topics to a CSV file
+// ExportTopicsToCSV exports current topics to a CSV file. If the file already
+// exists, its header (including extra columns), '#' comment lines and the
+// extra column values of rows that are still present are preserved.
 func (c *Config) ExportTopicsToCSV(path string) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(path)
@@ -365,31 +560,207 @@ func (c *Config) ExportTopicsToCSV(path string) error {
 		return err
 	}
 
-	// #nosec G304 -- path is user-provided output file path
-	file, err := os.Create(path)
+	existing, err := readCSVLayout(path)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = file.Close()
-	}()
+	for _, col := range []string{"category", "enabled", "tone", "length", "target_audience"} {
+		if hasColumn(existing.header, col) {
+			continue
+		}
+		for _, topic := range c.Topics {
+			if value, _ := topicField(topic, col); value != "" {
+				existing.header = append(existing.header, col)
+				break
+			}
+		}
+	}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	// Built in memory and swapped in whole, so a failed write cannot leave
+	// a truncated topics file
+	var file bytes.Buffer
+	writeComments := func(comments []string) error {
+		for _, comment := range comments {
+			if _, err := file.WriteString(comment + "\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := csv.NewWriter(&file)
 
 	// Write header
-	if err := writer.Write([]string{"name", "description", "keywords", "weight"}); err != nil {
+	if err := writeComments(existing.leading); err != nil {
+		return err
+	}
+	if err := writer.Write(existing.header); err != nil {
 		return err
 	}
 
 	// Write topics
 	for _, topic := range c.Topics {
-		keywords := strings.Join(topic.Keywords, ",")
-		weight := strconv.Itoa(topic.Weight)
-		if err := writer.Write([]string{topic.Name, topic.Description, keywords, weight}); err != nil {
+		row := existing.rows[topic.Name]
+		writer.Flush()
+		if err := writeComments(row.comments); err != nil {
+			return err
+		}
+
+		fields := make([]string, len(existing.header))
+		copy(fields, row.fields)
+		for i, col := range existing.header {
+			if value, ok := topicField(topic, col); ok {
+				fields[i] = value
+			}
+		}
+		// Unchanged rows are written as they were to keep diffs small
+		if row.raw != "" && slices.Equal(fields, row.fields) {
+			if _, err := file.WriteString(row.raw + "\n"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writer.Write(fields); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	if err := writeComments(existing.trailing); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, file.Bytes(), fileMode(path, 0644))
+}
+
+// csvLayout is the structure of an existing topics CSV file that should
+// survive a rewrite.
+type csvLayout struct {
+	header   []string
+	leading  []string // Comment lines before the header
+	trailing []string // Comment lines after the last row
+	rows     map[string]csvRow
+}
+
+// csvRow is an existing row with the comment lines directly above it.
+type csvRow struct {
+	comments []string
+	fields   []string
+	raw      string // The row as written in the file
+}
+
+// readCSVLayout reads the header, comments and rows of an existing topics
+// CSV file. A missing file yields the default header.
+func readCSVLayout(path string) (*csvLayout, error) {
+	layout := &csvLayout{
+		header: []string{"name", "description", "keywords", "weight"},
+		rows:   make(map[string]csvRow),
+	}
+
+	// #nosec G304 -- path is user-provided output file path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return layout, nil
+		}
+		return nil, err
+	}
+
+	var (
+		pending []string
+		record  strings.Builder
+		header  []string
+		nameIdx = -1
+	)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		// Comment and blank lines only count outside quoted multi-line fields
+		if record.Len() == 0 {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "#") {
+				pending = append(pending, line)
+				continue
+			}
+			if trimmed == "" {
+				continue
+			}
+		} else {
+			record.WriteString("\n")
+		}
+		record.WriteString(line)
+		if strings.Count(record.String(), `"`)%2 != 0 {
+			continue
+		}
+
+		raw := record.String()
+		fields, err := csv.NewReader(strings.NewReader(raw)).Read()
+		record.Reset()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse existing CSV: %w", err)
+		}
+
+		if header == nil {
+			header = fields
+			layout.header = fields
+			layout.leading = pending
+			pending = nil
+			for i, col := range header {
+				if strings.ToLower(strings.TrimSpace(col)) == "name" {
+					nameIdx = i
+				}
+			}
+			continue
+		}
+		if nameIdx != -1 && nameIdx < len(fields) {
+			layout.rows[strings.TrimSpace(fields[nameIdx])] = csvRow{comments: pending, fields: fields, raw: raw}
+		}
+		pending = nil
+	}
+	layout.trailing = pending
+
+	if nameIdx == -1 {
+		return nil, fmt.Errorf("existing CSV %s has no 'name' column", path)
+	}
+	return layout, nil
+}
+
+// topicField returns the value of a known topics CSV column for topic. ok is
+// false for columns the topic does not define.
+func topicField(topic TopicConfig, column string) (value string, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(column)) {
+	case "name":
+		return topic.Name, true
+	case "description":
+		return topic.Description, true
+	case "keywords":
+		return strings.Join(topic.Keywords, ","), true
+	case "weight":
+		return strconv.Itoa(topic.Weight), true
+	case "category":
+		return topic.Category, true
+	case "enabled":
+		if topic.Enabled == nil {
+			return "", true
+		}
+		return strconv.FormatBool(*topic.Enabled), true
+	case "tone":
+		return topic.Tone, true
+	case "length":
+		return topic.Length, true
+	case "target_audience":
+		return topic.TargetAudience, true
+	}
+	return "", false
+}
+
+func hasColumn(header []string, name string) bool {
+	for _, col := range header {
+		if strings.EqualFold(strings.TrimSpace(col), name) {
+			return true
+		}
+	}
+	return false
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -315,14 +316,14 @@ func TestLoadTopicsFromCSV(t *testing.T) {
 				t.Fatalf("Failed to write temp CSV: %v", err)
 			}
 
-			topics, err := loadTopicsFromCSV(csvPath)
+			topics, err := LoadTopicsFile(csvPath)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("loadTopicsFromCSV() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("LoadTopicsFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
 			if !tt.wantErr && len(topics) != tt.wantLen {
-				t.Errorf("loadTopicsFromCSV() len = %v, want %v", len(topics), tt.wantLen)
+				t.Errorf("LoadTopicsFile() len = %v, want %v", len(topics), tt.wantLen)
 			}
 		})
 	}
@@ -718,9 +719,9 @@ func TestLoadTopicsFromCSV_InvalidWeight(t *testing.T) {
 		t.Fatalf("Failed to write CSV: %v", err)
 	}
 
-	topics, err := loadTopicsFromCSV(csvPath)
+	topics, err := LoadTopicsFile(csvPath)
 	if err != nil {
-		t.Fatalf("loadTopicsFromCSV() unexpected error = %v", err)
+		t.Fatalf("LoadTopicsFile() unexpected error = %v", err)
 	}
 
 	// Invalid weight should default to 1
@@ -760,6 +761,298 @@ func TestSelectRandomTopic_WeightedDistribution(t *testing.T) {
 	}
 }
 
+func TestExportTopicsToCSV_PreservesExistingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvPath := filepath.Join(tmpDir, "topics.csv")
+	csvContent := `# Team topics - edit with care
+name,description,keywords,weight,owner
+# Go
+"Go Generics","Type parameters","generics,types",2,alice
+# AI
+"RAG Systems","Retrieval
+augmented generation","rag,embeddings",3,bob
+# end of file
+`
+	if err := os.WriteFile(csvPath, []byte(csvContent), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	existing, err := LoadTopicsFile(csvPath)
+	if err != nil {
+		t.Fatalf("LoadTopicsFile() error = %v", err)
+	}
+	if len(existing) != 2 {
+		t.Fatalf("LoadTopicsFile() = %d topics, want comments skipped", len(existing))
+	}
+
+	existing[0].Weight = 5
+	cfg := &Config{Topics: append(existing, TopicConfig{Name: "Observability", Keywords: []string{"otel"}, Weight: 1})}
+	if err := cfg.ExportTopicsToCSV(csvPath); err != nil {
+		t.Fatalf("ExportTopicsToCSV() error = %v", err)
+	}
+
+	// #nosec G304 -- csvPath is a test-controlled file path
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	want := `# Team topics - edit with care
+name,description,keywords,weight,owner
+# Go
+Go Generics,Type parameters,"generics,types",5,alice
+# AI
+"RAG Systems","Retrieval
+augmented generation","rag,embeddings",3,bob
+Observability,,otel,1,
+# end of file
+`
+	if string(data) != want {
+		t.Errorf("ExportTopicsToCSV() wrote:\n%s\nwant:\n%s", data, want)
+	}
+
+	reloaded, err := LoadTopicsFile(csvPath)
+	if err != nil {
+		t.Fatalf("LoadTopicsFile() after export error = %v", err)
+	}
+	if len(reloaded) != 3 {
+		t.Errorf("reloaded topics = %d, want 3", len(reloaded))
+	}
+}
+
+func TestLoad_SelectionDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	articlePromptPath := filepath.Join(tmpDir, "article-prompt.md")
+	systemPromptPath := filepath.Join(tmpDir, "system-prompt.md")
+	if err := os.WriteFile(articlePromptPath, []byte("test prompt"), 0600); err != nil {
+		t.Fatalf("Failed to write article prompt: %v", err)
+	}
+	if err := os.WriteFile(systemPromptPath, []byte("test system"), 0600); err != nil {
+		t.Fatalf("Failed to write system prompt: %v", err)
+	}
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	configContent := `
+prompt_template: ` + articlePromptPath + `
+system_prompt: ` + systemPromptPath + `
+topics:
+  - name: "Test"
+    weight: 1
+selection:
+  strategy: cooldown
+categories:
+  - name: AI
+    max_posts: 2
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Selection.Strategy != "cooldown" || cfg.Selection.CooldownDays != 21 {
+		t.Errorf("Selection = %+v, want cooldown with 21 days", cfg.Selection)
+	}
+	if cfg.Categories[0].PeriodDays != 30 {
+		t.Errorf("category period_days = %d, want default 30", cfg.Categories[0].PeriodDays)
+	}
+
+	neg := &Config{
+		Topics:    []TopicConfig{{Name: "Test", Weight: 1}},
+		Selection: SelectionConfig{Strategy: "cooldown", CooldownDays: -1},
+	}
+	if err := neg.Validate(); err == nil {
+		t.Error("Validate() should reject negative cooldown_days")
+	}
+}
+
+func TestTopicCategories_CSVRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvPath := filepath.Join(tmpDir, "topics.csv")
+	csvContent := `name,description,keywords,weight,category
+"Go Generics","Type parameters","generics",2,Go
+"Code Review","Reviewing well","review",1,
+`
+	if err := os.WriteFile(csvPath, []byte(csvContent), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	topics, err := LoadTopicsFile(csvPath)
+	if err != nil {
+		t.Fatalf("LoadTopicsFile() error = %v", err)
+	}
+	if topics[0].Category != "Go" || topics[1].Category != "" {
+		t.Errorf("categories = %q, %q, want Go and empty", topics[0].Category, topics[1].Category)
+	}
+
+	// Exporting to a file without a category column adds one
+	outPath := filepath.Join(tmpDir, "out.csv")
+	if err := (&Config{Topics: topics}).ExportTopicsToCSV(outPath); err != nil {
+		t.Fatalf("ExportTopicsToCSV() error = %v", err)
+	}
+	exported, err := LoadTopicsFile(outPath)
+	if err != nil {
+		t.Fatalf("LoadTopicsFile() error = %v", err)
+	}
+	if exported[0].Category != "Go" {
+		t.Errorf("exported category = %q, want Go", exported[0].Category)
+	}
+}
+
+func TestValidate_Categories(t *testing.T) {
+	base := func(categories ...CategoryConfig) *Config {
+		cfg := &Config{
+			AI:             AIConfig{Model: "test", MaxTokens: 100, TimeoutSeconds: 10},
+			Topics:         []TopicConfig{{Name: "Test", Weight: 1, Category: "Go"}},
+			Categories:     categories,
+			PromptTemplate: "config.go",
+			SystemPrompt:   "config.go",
+		}
+		return cfg
+	}
+
+	if err := base(CategoryConfig{Name: "Go", Weight: 2, MaxPosts: 2, PeriodDays: 30}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := base(CategoryConfig{Name: "Go"}, CategoryConfig{Name: "Go"}).Validate(); err == nil {
+		t.Error("Validate() should reject duplicate categories")
+	}
+	if err := base(CategoryConfig{Name: "AI", MaxPosts: -1}).Validate(); err == nil {
+		t.Error("Validate() should reject negative quotas")
+	}
+	if err := base(CategoryConfig{Weight: 1}).Validate(); err == nil {
+		t.Error("Validate() should reject categories without a name")
+	}
+}
+
+func TestValidate_Duplicates(t *testing.T) {
+	tests := []struct {
+		name    string
+		dup     DuplicateConfig
+		wantErr bool
+	}{
+		{"defaults", DuplicateConfig{}, false},
+		{"reject", DuplicateConfig{Threshold: 0.7, Action: DuplicateReject}, false},
+		{"threshold above 1", DuplicateConfig{Threshold: 1.5}, true},
+		{"unknown action", DuplicateConfig{Action: "warn"}, true},
+		{"negative attempts", DuplicateConfig{MaxAttempts: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				AI:             AIConfig{Model: "test", MaxTokens: 100, TimeoutSeconds: 10},
+				Topics:         []TopicConfig{{Name: "Test", Weight: 1}},
+				Duplicates:     tt.dup,
+				PromptTemplate: "config.go",
+				SystemPrompt:   "config.go",
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_KeywordsPerArticle(t *testing.T) {
+	tests := []struct {
+		n       int
+		wantErr bool
+	}{
+		{3, false},
+		{KeywordsAll, false},
+		{-2, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{
+			AI:             AIConfig{Model: "test", MaxTokens: 100, TimeoutSeconds: 10},
+			Topics:         []TopicConfig{{Name: "Test", Weight: 1}},
+			Style:          StyleConfig{KeywordsPerArticle: tt.n},
+			PromptTemplate: "config.go",
+			SystemPrompt:   "config.go",
+		}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with keywords_per_article %d error = %v, wantErr %v", tt.n, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoad_StorageDefaults(t *testing.T) {
+	for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "STORAGE_BACKEND"} {
+		t.Setenv(name, "")
+	}
+	tmpDir := t.TempDir()
+	promptPath := filepath.Join(tmpDir, "prompt.md")
+	if err := os.WriteFile(promptPath, []byte("test prompt"), 0600); err != nil {
+		t.Fatalf("Failed to write prompt: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		storage string
+		want    StorageConfig
+		wantErr bool
+	}{
+		{"default", "", StorageConfig{Backend: StorageJSON, Path: "articles.json"}, false},
+		{"sqlite", "storage:\n  backend: sqlite\n", StorageConfig{Backend: StorageSQLite, Path: "articles.db"}, false},
+		{"sqlite with path", "storage:\n  backend: sqlite\n  path: data/history.db\n", StorageConfig{Backend: StorageSQLite, Path: "data/history.db"}, false},
+		{"s3", "storage:\n  backend: s3\n  s3:\n    bucket: blog\n    path_style: true\n", StorageConfig{Backend: StorageS3, Path: "articles.json", S3: S3Config{Bucket: "blog", PathStyle: true}}, false},
+		{"git", "storage:\n  backend: git\n  git:\n    remote: origin\n", StorageConfig{Backend: StorageGit, Path: "articles.json", Git: GitConfig{Paths: []string{"archive", "generated"}, Remote: "origin"}}, false},
+		{"s3 without bucket", "storage:\n  backend: s3\n", StorageConfig{}, true},
+		{"unknown backend", "storage:\n  backend: etcd\n", StorageConfig{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := "prompt_template: " + promptPath + "\nsystem_prompt: " + promptPath + "\ntopics:\n  - name: Test\n    weight: 1\n" + tt.storage
+			if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := Load(configPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(cfg.Storage, tt.want) {
+				t.Errorf("Storage = %+v, want %+v", cfg.Storage, tt.want)
+			}
+		})
+	}
+
+	t.Run("env override", func(t *testing.T) {
+		t.Setenv("STORAGE_BACKEND", StorageGit)
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		content := "prompt_template: " + promptPath + "\nsystem_prompt: " + promptPath + "\ntopics:\n  - name: Test\n    weight: 1\n"
+		if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.Storage.Backend != StorageGit {
+			t.Errorf("Storage.Backend = %q, want git from STORAGE_BACKEND", cfg.Storage.Backend)
+		}
+	})
+}
+
+func TestValidate_Pricing(t *testing.T) {
+	cfg := &Config{
+		AI:             AIConfig{Model: "test", MaxTokens: 100, TimeoutSeconds: 10},
+		Topics:         []TopicConfig{{Name: "Test", Weight: 1}},
+		Pricing:        map[string]ModelPrice{"claude-sonnet-4": {Input: 3, Output: 15}},
+		PromptTemplate: "config.go",
+		SystemPrompt:   "config.go",
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	cfg.Pricing["claude-opus-4"] = ModelPrice{Input: -1}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject negative prices")
+	}
+}
+
 // Helper function for string containment check
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(s, substr)
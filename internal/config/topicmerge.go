@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Values of topics_precedence.
+const (
+	TopicsPrecedenceFiles  = "files"  // Topics files override inline topics
+	TopicsPrecedenceInline = "inline" // Inline topics override topics files
+)
+
+// TopicFiles lists the topics files of a config. In YAML it is either a
+// single path or a list; entries may be glob patterns such as "topics/*.csv".
+type TopicFiles []string
+
+// UnmarshalYAML accepts a single path as well as a list of paths.
+func (f *TopicFiles) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*f = nil
+		if value.Value != "" && value.Tag != "!!null" {
+			*f = TopicFiles{value.Value}
+		}
+		return nil
+	case yaml.SequenceNode:
+		var paths []string
+		if err := value.Decode(&paths); err != nil {
+			return err
+		}
+		*f = paths
+		return nil
+	}
+	return fmt.Errorf("line %d: topics_file must be a path or a list of paths", value.Line)
+}
+
+// Expand resolves glob patterns and returns the files in the order they are
+// listed, each pattern's matches sorted by name. A file matched twice is
+// returned once. A missing file or a pattern matching nothing is an error.
+func (f TopicFiles) Expand() ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, entry := range f {
+		matches := []string{entry}
+		if strings.ContainsAny(entry, "*?[") {
+			var err error
+			matches, err = filepath.Glob(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid topics_file pattern %q: %w", entry, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("topics_file pattern matches no files: %s", entry)
+			}
+			sort.Strings(matches)
+		} else if _, err := os.Stat(entry); err != nil {
+			return nil, fmt.Errorf("topics_file not found: %s", entry)
+		}
+
+		for _, file := range matches {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
+
+// TopicConflict is a topic defined differently in two sources.
+type TopicConflict struct {
+	Name       string
+	Source     string // Source whose definition is used
+	Overridden string // Source whose definition was dropped
+}
+
+func (c TopicConflict) String() string {
+	return fmt.Sprintf("topic %q: definition in %s overrides %s", c.Name, c.Source, c.Overridden)
+}
+
+// topicLayer is the topics read from one source.
+type topicLayer struct {
+	source string
+	topics []TopicConfig
+}
+
+// mergeTopicSources reads every topics file and merges it with the inline
+// topics of the config file at configPath. Later files override earlier
+// ones; topics_precedence decides between the files and the inline list.
+func (c *Config) mergeTopicSources(configPath string) error {
+	files, err := c.TopicsFile.Expand()
+	if err != nil {
+		return err
+	}
+
+	load := LoadTopicsFile
+	if c.StrictTopics {
+		load = LoadTopicsFileStrict
+	}
+	fileLayers := make([]topicLayer, 0, len(files))
+	for _, file := range files {
+		topics, err := load(file)
+		if err != nil {
+			return fmt.Errorf("failed to load topics from %s: %w", topicFormat(file), err)
+		}
+		fileLayers = append(fileLayers, topicLayer{source: file, topics: topics})
+	}
+
+	inline := topicLayer{source: configPath, topics: c.Topics}
+	layers := append([]topicLayer{inline}, fileLayers...)
+	if c.TopicsPrecedence == TopicsPrecedenceInline {
+		layers = append(fileLayers, inline)
+	}
+
+	c.Topics, c.TopicConflicts = mergeTopics(layers)
+	for _, conflict := range c.TopicConflicts {
+		slog.Warn("Conflicting topic definitions", "topic", conflict.Name, "using", conflict.Source, "ignoring", conflict.Overridden)
+	}
+	return nil
+}
+
+// mergeTopics merges layers by topic name, compared case-insensitively.
+// Each layer overrides the ones before it; a topic keeps the position where
+// it first appeared. Overrides that change the definition are returned as
+// conflicts.
+func mergeTopics(layers []topicLayer) ([]TopicConfig, []TopicConflict) {
+	var (
+		merged    []TopicConfig
+		conflicts []TopicConflict
+		index     = make(map[string]int)
+	)
+	for _, layer := range layers {
+		for _, topic := range layer.topics {
+			topic.Source = layer.source
+			key := strings.ToLower(strings.TrimSpace(topic.Name))
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(merged)
+				merged = append(merged, topic)
+				continue
+			}
+			if !sameTopic(merged[i], topic) {
+				conflicts = append(conflicts, TopicConflict{Name: topic.Name, Source: layer.source, Overridden: merged[i].Source})
+			}
+			merged[i] = topic
+		}
+	}
+	return merged, conflicts
+}
+
+// sameTopic reports whether two topics have the same definition, wherever
+// they were loaded from.
+func sameTopic(a, b TopicConfig) bool {
+	a.Source, b.Source = "", ""
+	return reflect.DeepEqual(a, b)
+}
+
+// topicFormat names the format of a topics file from its extension.
+func topicFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "YAML"
+	case ".json":
+		return "JSON"
+	default:
+		return "CSV"
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTopicFiles_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"single path", `topics_file: topics.csv`, []string{"topics.csv"}},
+		{"list", "topics_file:\n  - a.csv\n  - topics/*.yaml", []string{"a.csv", "topics/*.yaml"}},
+		{"empty", `topics_file:`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			if err := yaml.Unmarshal([]byte(tt.input), &cfg); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if strings.Join(cfg.TopicsFile, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("TopicsFile = %v, want %v", cfg.TopicsFile, tt.want)
+			}
+		})
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal([]byte("topics_file: {a: b}"), &cfg); err == nil {
+		t.Error("Unmarshal() should reject a mapping")
+	}
+}
+
+func TestTopicFiles_Expand(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"b.csv", "a.csv", "c.yaml"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("x"), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	c := filepath.Join(tmpDir, "c.yaml")
+
+	got, err := TopicFiles{c, filepath.Join(tmpDir, "*")}.Expand()
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	want := []string{c, filepath.Join(tmpDir, "a.csv"), filepath.Join(tmpDir, "b.csv")}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expand() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{filepath.Join(tmpDir, "missing.csv"), filepath.Join(tmpDir, "*.json")} {
+		if _, err := (TopicFiles{bad}).Expand(); err == nil {
+			t.Errorf("Expand(%s) should fail", bad)
+		}
+	}
+}
+
+func TestMergeTopics(t *testing.T) {
+	layers := []topicLayer{
+		{source: "config.yaml", topics: []TopicConfig{{Name: "Go", Weight: 1}, {Name: "Rust", Weight: 1}}},
+		{source: "a.csv", topics: []TopicConfig{{Name: "go", Weight: 5}, {Name: "RAG", Weight: 2}}},
+		{source: "b.csv", topics: []TopicConfig{{Name: "RAG", Weight: 2}}},
+	}
+	merged, conflicts := mergeTopics(layers)
+
+	var names []string
+	for _, topic := range merged {
+		names = append(names, topic.Name+"@"+topic.Source)
+	}
+	if got := strings.Join(names, ", "); got != "go@a.csv, Rust@config.yaml, RAG@b.csv" {
+		t.Errorf("merged = %s", got)
+	}
+	if merged[0].Weight != 5 {
+		t.Errorf("Go weight = %d, want the later definition's 5", merged[0].Weight)
+	}
+
+	// Identical definitions of RAG are not a conflict
+	if len(conflicts) != 1 || conflicts[0].Source != "a.csv" || conflicts[0].Overridden != "config.yaml" {
+		t.Errorf("conflicts = %+v, want one for Go", conflicts)
+	}
+}
+
+func TestLoad_MergesTopicSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+	writeFile("article.md", "prompt")
+	writeFile("system.md", "system")
+	writeFile("go.csv", "name,keywords,weight\nGo,\"go,generics\",3\n")
+	writeFile("ml.json", `[{"name": "RAG", "keywords": ["rag"], "weight": 2}]`)
+
+	for _, tt := range []struct {
+		precedence string
+		goWeight   int
+	}{
+		{"", 3},
+		{TopicsPrecedenceInline, 9},
+	} {
+		t.Run("precedence "+tt.precedence, func(t *testing.T) {
+			configPath := writeFile("config.yaml", `
+topics_file:
+  - `+filepath.Join(tmpDir, "*.csv")+`
+  - `+filepath.Join(tmpDir, "ml.json")+`
+topics_precedence: "`+tt.precedence+`"
+prompt_template: `+filepath.Join(tmpDir, "article.md")+`
+system_prompt: `+filepath.Join(tmpDir, "system.md")+`
+topics:
+  - name: Go
+    keywords: [go]
+    weight: 9
+  - name: Testing
+    keywords: [tests]
+`)
+			cfg, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(cfg.Topics) != 3 {
+				t.Fatalf("Topics = %+v, want Go, Testing and RAG", cfg.Topics)
+			}
+			if cfg.Topics[0].Weight != tt.goWeight {
+				t.Errorf("Go weight = %d, want %d", cfg.Topics[0].Weight, tt.goWeight)
+			}
+			if len(cfg.TopicConflicts) != 1 || cfg.TopicConflicts[0].Name != "Go" {
+				t.Errorf("TopicConflicts = %+v, want one for Go", cfg.TopicConflicts)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_TopicsPrecedence(t *testing.T) {
+	tmpDir := t.TempDir()
+	prompt := filepath.Join(tmpDir, "prompt.md")
+	_ = os.WriteFile(prompt, []byte("test"), 0600)
+
+	cfg := &Config{
+		AI:               AIConfig{MaxTokens: 1000, TimeoutSeconds: 60},
+		Topics:           []TopicConfig{{Name: "Go", Weight: 1}},
+		PromptTemplate:   prompt,
+		SystemPrompt:     prompt,
+		TopicsPrecedence: "newest",
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject an unknown topics_precedence")
+	}
+}
@@ -0,0 +1,379 @@
+package config
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// topicColumns are the columns understood in a topics CSV file.
+var topicColumns = map[string]bool{
+	"name":            true,
+	"description":     true,
+	"keywords":        true,
+	"weight":          true,
+	"category":        true,
+	"enabled":         true,
+	"tone":            true,
+	"length":          true,
+	"target_audience": true,
+}
+
+// issueUnknownColumn is the message of a TopicIssue for a CSV column that
+// is not a topic field.
+const issueUnknownColumn = "unknown column"
+
+// TopicIssue is a problem found on one line of a topics file.
+type TopicIssue struct {
+	Line    int
+	Column  string // Empty when the issue concerns the whole row
+	Message string
+}
+
+func (i TopicIssue) String() string {
+	if i.Column == "" {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Column, i.Message)
+}
+
+// TopicsFileError aggregates every issue found in a topics file.
+type TopicsFileError struct {
+	Path   string
+	Issues []TopicIssue
+}
+
+func (e *TopicsFileError) Error() string {
+	lines := make([]string, 0, len(e.Issues)+1)
+	lines = append(lines, fmt.Sprintf("%s: %d problem(s)", e.Path, len(e.Issues)))
+	for _, issue := range e.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoadTopicsFile reads the topics defined in a CSV, YAML or JSON topics
+// file. Problems in individual rows are logged and the rows skipped or
+// defaulted.
+func LoadTopicsFile(path string) ([]TopicConfig, error) {
+	topics, issues, err := readTopicsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		slog.Warn("Problem in topics file", "path", path, "line", issue.Line, "column", issue.Column, "problem", issue.Message)
+	}
+	return topics, nil
+}
+
+// LoadTopicsFileStrict reads a topics file and fails with a *TopicsFileError
+// listing every problem: bad weights or enabled flags, duplicate or empty
+// names, unknown columns, short rows and empty keywords.
+func LoadTopicsFileStrict(path string) ([]TopicConfig, error) {
+	topics, issues, err := readTopicsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) > 0 {
+		return nil, &TopicsFileError{Path: path, Issues: issues}
+	}
+	return topics, nil
+}
+
+func readTopicsFile(path string) ([]TopicConfig, []TopicIssue, error) {
+	// #nosec G304 -- path is from config file, user-controlled
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	switch topicFormat(path) {
+	case "YAML":
+		return parseTopicsYAML(file)
+	case "JSON":
+		return parseTopicsJSON(file)
+	default:
+		return parseTopicsCSV(file)
+	}
+}
+
+// parseTopicsCSV reads topics from CSV and reports row-level issues. Rows
+// with an empty or duplicate name are skipped; an invalid weight or enabled
+// flag falls back to its default. Structural problems (unreadable CSV, no
+// data rows, no name column) are returned as an error instead.
+func parseTopicsCSV(r io.Reader) ([]TopicConfig, []TopicIssue, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("CSV file must have header and at least one data row")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	headerLine, _ := reader.FieldPos(0)
+
+	var issues []TopicIssue
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		name := strings.ToLower(strings.TrimSpace(col))
+		if !topicColumns[name] {
+			issues = append(issues, TopicIssue{Line: headerLine, Column: col, Message: issueUnknownColumn})
+			continue
+		}
+		if _, dup := columns[name]; dup {
+			issues = append(issues, TopicIssue{Line: headerLine, Column: col, Message: "duplicate column"})
+			continue
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, nil, fmt.Errorf("CSV must have 'name' column")
+	}
+
+	var (
+		topics []TopicConfig
+		rows   int
+		seen   = make(map[string]int) // lower-cased name -> line
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows++
+
+		if len(record) < len(header) {
+			issues = append(issues, TopicIssue{Line: line, Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record))})
+		}
+		field := func(name string) (string, bool) {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return "", false
+			}
+			return strings.TrimSpace(record[i]), true
+		}
+
+		topic := TopicConfig{Weight: 1}
+		topic.Name, _ = field("name")
+		topic.Description, _ = field("description")
+		topic.Category, _ = field("category")
+		topic.Tone, _ = field("tone")
+		topic.Length, _ = field("length")
+		topic.TargetAudience, _ = field("target_audience")
+
+		if value, ok := field("keywords"); ok {
+			if value == "" {
+				issues = append(issues, TopicIssue{Line: line, Column: "keywords", Message: "no keywords"})
+			}
+			for _, kw := range strings.Split(value, ",") {
+				if kw = strings.TrimSpace(kw); kw != "" {
+					topic.Keywords = append(topic.Keywords, kw)
+				} else if value != "" {
+					issues = append(issues, TopicIssue{Line: line, Column: "keywords", Message: fmt.Sprintf("empty keyword in %q", value)})
+				}
+			}
+		}
+
+		if value, ok := field("weight"); ok && value != "" {
+			weight, err := strconv.Atoi(value)
+			switch {
+			case err != nil:
+				issues = append(issues, TopicIssue{Line: line, Column: "weight", Message: fmt.Sprintf("%q is not a whole number", value)})
+			case weight < 0:
+				issues = append(issues, TopicIssue{Line: line, Column: "weight", Message: fmt.Sprintf("%d is negative", weight)})
+			default:
+				topic.Weight = weight
+			}
+		}
+
+		if value, ok := field("enabled"); ok && value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				issues = append(issues, TopicIssue{Line: line, Column: "enabled", Message: fmt.Sprintf("%q is not true or false", value)})
+			} else {
+				topic.Enabled = &enabled
+			}
+		}
+
+		if topic.Name == "" {
+			issues = append(issues, TopicIssue{Line: line, Column: "name", Message: "empty name, row skipped"})
+			continue
+		}
+		key := strings.ToLower(topic.Name)
+		if first, dup := seen[key]; dup {
+			issues = append(issues, TopicIssue{Line: line, Column: "name", Message: fmt.Sprintf("duplicate of %q on line %d, row skipped", topic.Name, first)})
+			continue
+		}
+		seen[key] = line
+
+		topics = append(topics, topic)
+	}
+
+	if rows == 0 {
+		return nil, nil, fmt.Errorf("CSV file must have header and at least one data row")
+	}
+	return topics, issues, nil
+}
+
+// topicItem is one entry of a YAML or JSON topics list before checking.
+type topicItem struct {
+	line   int
+	fields []string
+	topic  TopicConfig
+	err    error
+}
+
+// parseTopicsYAML reads a YAML list of topics, or a mapping with a topics
+// key such as a config file, and reports the same issues as the CSV parser.
+func parseTopicsYAML(r io.Reader) ([]TopicConfig, []TopicIssue, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("YAML file must contain a list of topics")
+		}
+		return nil, nil, err
+	}
+
+	list := doc.Content[0]
+	if list.Kind == yaml.MappingNode {
+		var found *yaml.Node
+		for i := 0; i+1 < len(list.Content); i += 2 {
+			if list.Content[i].Value == "topics" {
+				found = list.Content[i+1]
+			}
+		}
+		if found == nil {
+			return nil, nil, fmt.Errorf("YAML file has no topics key")
+		}
+		list = found
+	}
+	if list.Kind != yaml.SequenceNode {
+		return nil, nil, fmt.Errorf("line %d: topics must be a list", list.Line)
+	}
+
+	items := make([]topicItem, 0, len(list.Content))
+	for _, node := range list.Content {
+		item := topicItem{line: node.Line}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			item.fields = append(item.fields, node.Content[i].Value)
+		}
+		item.err = node.Decode(&item.topic)
+		items = append(items, item)
+	}
+	return checkTopicItems(items)
+}
+
+// parseTopicsJSON reads a JSON array of topics, or an object with a topics
+// key, and reports the same issues as the CSV parser.
+func parseTopicsJSON(r io.Reader) ([]TopicConfig, []TopicIssue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw := json.RawMessage(data)
+	offset := 0
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal(data, &wrapper) == nil {
+		topics, ok := wrapper["topics"]
+		if !ok {
+			return nil, nil, fmt.Errorf("JSON object has no topics key")
+		}
+		raw = topics
+		offset = bytes.Index(data, topics)
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, nil, fmt.Errorf("topics must be a JSON array: %w", err)
+	}
+
+	items := make([]topicItem, 0, len(entries))
+	for _, entry := range entries {
+		// Locate each entry in data to report its line number
+		offset += bytes.Index(data[offset:], entry)
+		item := topicItem{line: 1 + bytes.Count(data[:offset], []byte("\n"))}
+		offset += len(entry)
+
+		var fields map[string]json.RawMessage
+		if item.err = json.Unmarshal(entry, &fields); item.err == nil {
+			for name := range fields {
+				item.fields = append(item.fields, name)
+			}
+			sort.Strings(item.fields)
+			item.err = json.Unmarshal(entry, &item.topic)
+		}
+		items = append(items, item)
+	}
+	return checkTopicItems(items)
+}
+
+// checkTopicItems validates decoded YAML or JSON topics. Entries that fail
+// to decode or have an empty or duplicate name are skipped; a negative
+// weight falls back to 1.
+func checkTopicItems(items []topicItem) ([]TopicConfig, []TopicIssue, error) {
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("topics file must have at least one topic")
+	}
+
+	var (
+		topics []TopicConfig
+		issues []TopicIssue
+		seen   = make(map[string]int) // lower-cased name -> line
+	)
+	for _, item := range items {
+		if item.err != nil {
+			issues = append(issues, TopicIssue{Line: item.line, Message: fmt.Sprintf("%v, topic skipped", item.err)})
+			continue
+		}
+		for _, field := range item.fields {
+			if !topicColumns[field] && field != "sources" {
+				issues = append(issues, TopicIssue{Line: item.line, Column: field, Message: "unknown field"})
+			}
+		}
+
+		topic := item.topic
+		topic.Name = strings.TrimSpace(topic.Name)
+		if topic.Weight < 0 {
+			issues = append(issues, TopicIssue{Line: item.line, Column: "weight", Message: fmt.Sprintf("%d is negative", topic.Weight)})
+			topic.Weight = 1
+		}
+		if len(topic.Keywords) == 0 {
+			issues = append(issues, TopicIssue{Line: item.line, Column: "keywords", Message: "no keywords"})
+		}
+
+		if topic.Name == "" {
+			issues = append(issues, TopicIssue{Line: item.line, Column: "name", Message: "empty name, topic skipped"})
+			continue
+		}
+		key := strings.ToLower(topic.Name)
+		if first, dup := seen[key]; dup {
+			issues = append(issues, TopicIssue{Line: item.line, Column: "name", Message: fmt.Sprintf("duplicate of %q on line %d, topic skipped", topic.Name, first)})
+			continue
+		}
+		seen[key] = item.line
+
+		topics = append(topics, topic)
+	}
+	return topics, issues, nil
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTopicsCSV_Issues(t *testing.T) {
+	input := `name,description,keywords,weight,owner,enabled
+# comment lines do not shift line numbers
+"Go Generics","Type parameters","generics,types",2,alice,true
+"RAG Systems","Retrieval","rag,,embeddings",heavy,bob,
+"go generics","Again","generics",1,carol,false
+"","No name","x",1,dave,
+"Observability","Tracing","",1,erin,maybe
+"Short Row","Too few"
+`
+	topics, issues, err := parseTopicsCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseTopicsCSV() error = %v", err)
+	}
+
+	want := []string{
+		"line 1: owner: unknown column",
+		`line 4: keywords: empty keyword in "rag,,embeddings"`,
+		`line 4: weight: "heavy" is not a whole number`,
+		`line 5: name: duplicate of "go generics" on line 3, row skipped`,
+		"line 6: name: empty name, row skipped",
+		"line 7: keywords: no keywords",
+		`line 7: enabled: "maybe" is not true or false`,
+		"line 8: expected 6 fields, got 2",
+	}
+	got := make([]string, len(issues))
+	for i, issue := range issues {
+		got[i] = issue.String()
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if len(topics) != 4 {
+		t.Fatalf("parseTopicsCSV() = %d topics, want 4", len(topics))
+	}
+	if topics[1].Weight != 1 || len(topics[1].Keywords) != 2 {
+		t.Errorf("RAG topic = %+v, want default weight and two keywords", topics[1])
+	}
+	if !topics[0].IsEnabled() || topics[0].Enabled == nil || !topics[2].IsEnabled() {
+		t.Error("topics should be enabled unless the column says false")
+	}
+}
+
+func TestParseTopicsCSV_Overrides(t *testing.T) {
+	input := `name,keywords,enabled,tone,length,target_audience
+"Go Generics","generics",false,casual,short,beginners
+`
+	topics, issues, err := parseTopicsCSV(strings.NewReader(input))
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("parseTopicsCSV() error = %v, issues = %v", err, issues)
+	}
+
+	topic := topics[0]
+	if topic.IsEnabled() {
+		t.Error("topic should be disabled")
+	}
+
+	cfg := &Config{
+		Topics: topics,
+		Style:  StyleConfig{Tone: "professional", Length: "medium", TargetAudience: "intermediate", IncludeCode: true},
+	}
+	style := cfg.StyleFor(&topic)
+	if style.Tone != "casual" || style.Length != "short" || style.TargetAudience != "beginners" || !style.IncludeCode {
+		t.Errorf("StyleFor() = %+v", style)
+	}
+	if cfg.StyleFor(nil) != cfg.Style {
+		t.Error("StyleFor(nil) should return the global style")
+	}
+	if len(cfg.EnabledTopics()) != 0 {
+		t.Error("EnabledTopics() should skip disabled topics")
+	}
+}
+
+func TestLoadTopicsFileStrict(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	badPath := filepath.Join(tmpDir, "bad.csv")
+	if err := os.WriteFile(badPath, []byte("name,weight\nA,1\nA,x\n"), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	_, err := LoadTopicsFileStrict(badPath)
+	var fileErr *TopicsFileError
+	if !errors.As(err, &fileErr) {
+		t.Fatalf("LoadTopicsFileStrict() error = %v, want *TopicsFileError", err)
+	}
+	if len(fileErr.Issues) != 2 || !strings.Contains(err.Error(), "2 problem(s)") {
+		t.Errorf("error = %v", err)
+	}
+
+	// Lenient loading keeps the first row and logs the rest
+	topics, err := LoadTopicsFile(badPath)
+	if err != nil || len(topics) != 1 {
+		t.Errorf("LoadTopicsFile() = %v, %v", topics, err)
+	}
+
+	goodPath := filepath.Join(tmpDir, "good.csv")
+	if err := os.WriteFile(goodPath, []byte("name,keywords,weight\nA,\"a,b\",1\n"), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	if topics, err := LoadTopicsFileStrict(goodPath); err != nil || len(topics) != 1 {
+		t.Errorf("LoadTopicsFileStrict() = %v, %v", topics, err)
+	}
+}
+
+func TestLoad_StrictTopics(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvPath := filepath.Join(tmpDir, "topics.csv")
+	if err := os.WriteFile(csvPath, []byte("name,weight\nA,-2\n"), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("topics_file: "+csvPath+"\nstrict_topics: true\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	var fileErr *TopicsFileError
+	if !errors.As(err, &fileErr) || fileErr.Issues[0].Line != 2 {
+		t.Errorf("Load() error = %v, want a TopicsFileError for line 2", err)
+	}
+}
+
+func TestParseTopicsStructured_Issues(t *testing.T) {
+	yamlInput := `topics:
+  - name: Go
+    keywords: [go]
+  - name: go
+    keywords: [dup]
+  - name: Rust
+    weight: -1
+    owner: systems
+`
+	jsonInput := `[
+  {"name": "Go", "keywords": ["go"]},
+  {"name": "go", "keywords": ["dup"]},
+  {"name": "Rust", "weight": -1,
+   "owner": "systems"}
+]`
+	want := []string{
+		`line 4: name: duplicate of "go" on line 2, topic skipped`,
+		"line 6: owner: unknown field",
+		"line 6: weight: -1 is negative",
+		"line 6: keywords: no keywords",
+	}
+	jsonWant := strings.NewReplacer("line 4", "line 3", "line 6", "line 4").Replace(strings.Join(want, "\n"))
+
+	for _, tt := range []struct {
+		name  string
+		parse func(io.Reader) ([]TopicConfig, []TopicIssue, error)
+		input string
+		want  string
+	}{
+		{"YAML", parseTopicsYAML, yamlInput, strings.Join(want, "\n")},
+		{"JSON", parseTopicsJSON, jsonInput, jsonWant},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			topics, issues, err := tt.parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if len(topics) != 2 || topics[1].Weight != 1 {
+				t.Errorf("topics = %+v, want Go and Rust (weight reset to 1)", topics)
+			}
+			got := make([]string, len(issues))
+			for i, issue := range issues {
+				got[i] = issue.String()
+			}
+			if strings.Join(got, "\n") != tt.want {
+				t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), tt.want)
+			}
+		})
+	}
+
+	if _, _, err := parseTopicsYAML(strings.NewReader("ai:\n  model: x\n")); err == nil {
+		t.Error("parseTopicsYAML() should fail without a topics key")
+	}
+	if _, _, err := parseTopicsJSON(strings.NewReader(`"topics"`)); err == nil {
+		t.Error("parseTopicsJSON() should fail on a non-array")
+	}
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TopicSource is a place topics are edited: a topics file (CSV, YAML or
+// JSON) or the inline topics list in the config file.
+type TopicSource struct {
+	path   string
+	inline bool
+}
+
+// TopicSources returns the sources of a loaded configuration: each topics
+// file in order, then the config file's inline topics. New topics are added
+// to the first source.
+func TopicSources(configPath string, cfg *Config) ([]*TopicSource, error) {
+	files, err := cfg.TopicsFile.Expand()
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]*TopicSource, 0, len(files)+1)
+	for _, file := range files {
+		sources = append(sources, &TopicSource{path: file})
+	}
+	return append(sources, &TopicSource{path: configPath, inline: true}), nil
+}
+
+// Path returns the file topics are read from and written to.
+func (s *TopicSource) Path() string {
+	return s.path
+}
+
+// Load reads the topics as written in the source, including disabled topics
+// and without the built-in default topic. Saving writes back only what was
+// read, so a topics file with problems that would be lost, such as skipped
+// rows or invalid weights, is refused with a *TopicsFileError. Unknown CSV
+// columns are kept by Save and allowed.
+func (s *TopicSource) Load() ([]TopicConfig, error) {
+	if !s.inline {
+		topics, issues, err := readTopicsFile(s.path)
+		if err != nil {
+			return nil, err
+		}
+		if topicFormat(s.path) == "CSV" {
+			issues = slices.DeleteFunc(issues, func(i TopicIssue) bool { return i.Message == issueUnknownColumn })
+		}
+		if len(issues) > 0 {
+			return nil, &TopicsFileError{Path: s.path, Issues: issues}
+		}
+		return topics, nil
+	}
+
+	// #nosec G304 -- path is provided by user as configuration file path
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var inline struct {
+		Topics []TopicConfig `yaml:"topics"`
+	}
+	if err := yaml.Unmarshal(data, &inline); err != nil {
+		return nil, err
+	}
+	return inline.Topics, nil
+}
+
+// Save replaces the topics in the source. CSV files keep their comments and
+// extra columns; YAML files keep everything outside the topics list.
+func (s *TopicSource) Save(topics []TopicConfig) error {
+	if s.inline {
+		return saveInlineTopics(s.path, topics)
+	}
+	switch topicFormat(s.path) {
+	case "YAML":
+		return saveInlineTopics(s.path, topics)
+	case "JSON":
+		return saveJSONTopics(s.path, topics)
+	default:
+		return (&Config{Topics: topics}).ExportTopicsToCSV(s.path)
+	}
+}
+
+// saveInlineTopics rewrites the topics key of a YAML config file, or the
+// whole document when it is a bare list of topics.
+func saveInlineTopics(path string, topics []TopicConfig) error {
+	// #nosec G304 -- path is provided by user as configuration file path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	var list yaml.Node
+	if err := list.Encode(topics); err != nil {
+		return err
+	}
+
+	root := doc.Content[0]
+	switch root.Kind {
+	case yaml.SequenceNode:
+		keepComments(root, &list)
+		doc.Content[0] = &list
+		return writeKeepingMode(path, &doc)
+	case yaml.MappingNode:
+	default:
+		return fmt.Errorf("%s: top level is not a mapping or a list", path)
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "topics" {
+			keepComments(root.Content[i+1], &list)
+			root.Content[i+1] = &list
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "topics"}, &list)
+	}
+	return writeKeepingMode(path, &doc)
+}
+
+// writeKeepingMode encodes a YAML document over an existing file.
+func writeKeepingMode(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return replaceFile(path, buf.Bytes())
+}
+
+// saveJSONTopics rewrites a JSON topics file, keeping the other keys of a
+// file that wraps the list in an object.
+func saveJSONTopics(path string, topics []TopicConfig) error {
+	// #nosec G304 -- path is from config file, user-controlled
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var out any = topics
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal(data, &wrapper) == nil {
+		list, err := json.Marshal(topics)
+		if err != nil {
+			return err
+		}
+		wrapper["topics"] = list
+		out = wrapper
+	}
+
+	encoded, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return replaceFile(path, append(encoded, '\n'))
+}
+
+// replaceFile overwrites an existing file, keeping its permissions.
+func replaceFile(path string, data []byte) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, fileMode(path, 0))
+}
+
+// fileMode returns the permissions of an existing file, or def.
+func fileMode(path string, def os.FileMode) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	return def
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so a failed write leaves the old content in place.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// keepComments copies comments from an existing topics sequence to its
+// replacement, matching items by topic name.
+func keepComments(old, replacement *yaml.Node) {
+	replacement.HeadComment = old.HeadComment
+	replacement.LineComment = old.LineComment
+	replacement.FootComment = old.FootComment
+
+	byName := make(map[string]*yaml.Node, len(old.Content))
+	for _, item := range old.Content {
+		byName[topicNodeName(item)] = item
+	}
+	for _, item := range replacement.Content {
+		if prev, ok := byName[topicNodeName(item)]; ok {
+			item.HeadComment = prev.HeadComment
+			item.LineComment = prev.LineComment
+			item.FootComment = prev.FootComment
+		}
+	}
+}
+
+func topicNodeName(item *yaml.Node) string {
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		if item.Content[i].Value == "name" {
+			return item.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// FindTopic returns the index of the topic with the given name, compared
+// case-insensitively, or -1.
+func FindTopic(topics []TopicConfig, name string) int {
+	for i, t := range topics {
+		if strings.EqualFold(strings.TrimSpace(t.Name), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
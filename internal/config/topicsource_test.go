@@ -0,0 +1,202 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTopicSource_CSV(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvPath := filepath.Join(tmpDir, "topics.csv")
+	original := `name,description,keywords,weight
+"Go Generics","Type parameters","generics,types",2
+"RAG Systems","Retrieval","rag",1
+`
+	if err := os.WriteFile(csvPath, []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	sources, err := TopicSources("config.yaml", &Config{TopicsFile: TopicFiles{csvPath}})
+	if err != nil {
+		t.Fatalf("TopicSources() error = %v", err)
+	}
+	if len(sources) != 2 || sources[0].Path() != csvPath || sources[1].Path() != "config.yaml" {
+		t.Fatalf("TopicSources() = %v, want the CSV then the config file", sources)
+	}
+	source := sources[0]
+	topics, err := source.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	disabled := false
+	topics[1].Enabled = &disabled
+	if err := source.Save(topics); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := source.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if reloaded[0].IsEnabled() != true || reloaded[1].IsEnabled() != false {
+		t.Errorf("enabled flags = %v, %v", reloaded[0].IsEnabled(), reloaded[1].IsEnabled())
+	}
+}
+
+func TestTopicSource_RefusesLossyFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{"duplicate name", "topics.csv", "name,keywords\nGo,go\ngo,golang\n", true},
+		{"invalid weight", "topics.csv", "name,keywords,weight\nGo,go,heavy\n", true},
+		{"unknown CSV column is kept", "topics.csv", "name,keywords,owner\nGo,go,alice\n", false},
+		{"unknown YAML field", "topics.yaml", "- name: Go\n  keywords: [go]\n  owner: alice\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := (&TopicSource{path: path}).Load()
+			var fileErr *TopicsFileError
+			if got := errors.As(err, &fileErr); got != tt.wantErr {
+				t.Errorf("Load() error = %v, want a TopicsFileError: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic_KeepsFileOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topics.csv")
+	if err := os.WriteFile(path, []byte("name\nGo\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "missing", "topics.csv"), []byte("x"), 0600); err == nil {
+		t.Error("writeFileAtomic() into a missing directory should fail")
+	}
+	if err := writeFileAtomic(path, []byte("name\nRust\n"), 0640); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want no temporary files left", len(entries))
+	}
+}
+
+func TestTopicSource_Inline(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	original := `# Blog settings
+ai:
+  model: "claude-sonnet-4-20250514"  # keep in sync with the workflow
+
+topics:
+  # Our core topic
+  - name: "Go Generics"
+    keywords: ["generics"]
+    weight: 2
+`
+	if err := os.WriteFile(configPath, []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	sources, err := TopicSources(configPath, &Config{})
+	if err != nil {
+		t.Fatalf("TopicSources() error = %v", err)
+	}
+	source := sources[0]
+	topics, err := source.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(topics) != 1 || topics[0].Weight != 2 {
+		t.Fatalf("Load() = %+v", topics)
+	}
+
+	topics[0].Weight = 3
+	topics = append(topics, TopicConfig{Name: "RAG Systems", Keywords: []string{"rag"}, Weight: 1})
+	if err := source.Save(topics); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// #nosec G304 -- configPath is a test-controlled file path
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{"# Blog settings", "# keep in sync with the workflow", "# Our core topic", "weight: 3", "name: RAG Systems"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saved config missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "description") || strings.Contains(out, "enabled") {
+		t.Errorf("saved config should omit empty optional fields:\n%s", out)
+	}
+}
+
+func TestTopicSource_StructuredFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "team.yaml")
+	jsonPath := filepath.Join(tmpDir, "team.json")
+	if err := os.WriteFile(yamlPath, []byte("# Owned by the platform team\n- name: Kubernetes\n  keywords: [k8s]\n"), 0600); err != nil {
+		t.Fatalf("Failed to write YAML: %v", err)
+	}
+	if err := os.WriteFile(jsonPath, []byte(`{"owner": "ml", "topics": [{"name": "RAG", "keywords": ["rag"]}]}`), 0600); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		source := &TopicSource{path: path}
+		topics, err := source.Load()
+		if err != nil || len(topics) != 1 {
+			t.Fatalf("%s: Load() = %v, %v", path, topics, err)
+		}
+		topics[0].Weight = 4
+		topics = append(topics, TopicConfig{Name: "Added", Keywords: []string{"new"}})
+		if err := source.Save(topics); err != nil {
+			t.Fatalf("%s: Save() error = %v", path, err)
+		}
+		reloaded, err := source.Load()
+		if err != nil || len(reloaded) != 2 || reloaded[0].Weight != 4 {
+			t.Errorf("%s: reloaded = %+v, %v", path, reloaded, err)
+		}
+	}
+
+	// #nosec G304 -- test-controlled file paths
+	yamlData, _ := os.ReadFile(yamlPath)
+	if !strings.Contains(string(yamlData), "# Owned by the platform team") {
+		t.Errorf("YAML file lost its comment:\n%s", yamlData)
+	}
+	// #nosec G304 -- test-controlled file paths
+	jsonData, _ := os.ReadFile(jsonPath)
+	if !strings.Contains(string(jsonData), `"owner": "ml"`) {
+		t.Errorf("JSON file lost its other keys:\n%s", jsonData)
+	}
+}
+
+func TestFindTopic(t *testing.T) {
+	topics := []TopicConfig{{Name: "Go Generics"}, {Name: "RAG Systems"}}
+	if got := FindTopic(topics, " rag systems "); got != 1 {
+		t.Errorf("FindTopic() = %d, want 1", got)
+	}
+	if got := FindTopic(topics, "Rust"); got != -1 {
+		t.Errorf("FindTopic() = %d, want -1", got)
+	}
+}
@@ -0,0 +1,215 @@
+// Package feed reads RSS 2.0 and Atom feeds from files or URLs.
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Item is a single feed entry.
+type Item struct {
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Feed      string // Title of the feed the item came from
+}
+
+type rssDocument struct {
+	Channel struct {
+		Title string `xml:"title"`
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+			Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomDocument struct {
+	Title   string `xml:"title"`
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+// maxFeedSize bounds how much of a feed is read into memory.
+const maxFeedSize = 10 << 20
+
+// Parse decodes an RSS or Atom document, detected from its root element.
+func Parse(r io.Reader) ([]Item, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "rss":
+		return parseRSS(data)
+	case "feed":
+		return parseAtom(data)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: <%s>", root)
+	}
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to read feed: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func parseRSS(data []byte) ([]Item, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+	}
+
+	items := make([]Item, 0, len(doc.Channel.Items))
+	for _, it := range doc.Channel.Items {
+		date := it.PubDate
+		if date == "" {
+			date = it.Date
+		}
+		items = append(items, Item{
+			Title:     strings.TrimSpace(it.Title),
+			Link:      strings.TrimSpace(it.Link),
+			Summary:   strings.TrimSpace(it.Description),
+			Published: parseDate(date),
+			Feed:      strings.TrimSpace(doc.Channel.Title),
+		})
+	}
+	return items, nil
+}
+
+func parseAtom(data []byte) ([]Item, error) {
+	var doc atomDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+	}
+
+	items := make([]Item, 0, len(doc.Entries))
+	for _, e := range doc.Entries {
+		item := Item{
+			Title:   strings.TrimSpace(e.Title),
+			Summary: strings.TrimSpace(e.Summary),
+			Feed:    strings.TrimSpace(doc.Title),
+		}
+		if item.Summary == "" {
+			item.Summary = strings.TrimSpace(e.Content)
+		}
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				item.Link = l.Href
+				break
+			}
+		}
+		date := e.Published
+		if date == "" {
+			date = e.Updated
+		}
+		item.Published = parseDate(date)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02",
+}
+
+// parseDate tries the date formats commonly found in feeds. It returns the
+// zero time when none match.
+func parseDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Fetch reads a feed from an http(s) URL or a local file path.
+func Fetch(ctx context.Context, client *http.Client, source string) ([]Item, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		// #nosec G304 -- feed path is provided by the user
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		return Parse(file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch feed %s (status %d)", source, resp.StatusCode)
+	}
+
+	return Parse(resp.Body)
+}
+
+// Since returns the items published at or after cutoff. Items without a
+// parseable date are kept.
+func Since(items []Item, cutoff time.Time) []Item {
+	var recent []Item
+	for _, item := range items {
+		if item.Published.IsZero() || !item.Published.Before(cutoff) {
+			recent = append(recent, item)
+		}
+	}
+	return recent
+}
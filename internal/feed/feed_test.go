@@ -0,0 +1,130 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Go Weekly</title>
+    <item>
+      <title>Structured logging with slog</title>
+      <link>https://example.com/slog</link>
+      <description>A tour of log/slog</description>
+      <pubDate>Mon, 06 Oct 2025 09:00:00 +0000</pubDate>
+    </item>
+    <item>
+      <title>Undated item</title>
+      <link>https://example.com/undated</link>
+    </item>
+  </channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>AI Notes</title>
+  <entry>
+    <title>Evaluating RAG pipelines</title>
+    <link rel="self" href="https://example.com/self"/>
+    <link href="https://example.com/rag"/>
+    <content>Long form content</content>
+    <updated>2025-09-01T12:00:00Z</updated>
+  </entry>
+</feed>`
+
+func TestParse(t *testing.T) {
+	items, err := Parse(strings.NewReader(rssFeed))
+	if err != nil {
+		t.Fatalf("Parse(rss) error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Parse(rss) items = %d, want 2", len(items))
+	}
+	if items[0].Title != "Structured logging with slog" || items[0].Feed != "Go Weekly" {
+		t.Errorf("rss item = %+v", items[0])
+	}
+	if want := time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC); !items[0].Published.Equal(want) {
+		t.Errorf("rss Published = %v, want %v", items[0].Published, want)
+	}
+	if !items[1].Published.IsZero() {
+		t.Error("undated item should have zero Published time")
+	}
+
+	items, err = Parse(strings.NewReader(atomFeed))
+	if err != nil {
+		t.Fatalf("Parse(atom) error = %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Parse(atom) items = %d, want 1", len(items))
+	}
+	if items[0].Link != "https://example.com/rag" {
+		t.Errorf("atom Link = %q, want alternate link", items[0].Link)
+	}
+	if items[0].Summary != "Long form content" {
+		t.Errorf("atom Summary = %q, want content fallback", items[0].Summary)
+	}
+
+	if _, err := Parse(strings.NewReader(`<html><body/></html>`)); err == nil {
+		t.Error("Parse() should reject non-feed XML")
+	}
+	if _, err := Parse(strings.NewReader(`not xml`)); err == nil {
+		t.Error("Parse() should reject invalid input")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(rssFeed))
+	}))
+	defer server.Close()
+
+	items, err := Fetch(t.Context(), server.Client(), server.URL+"/feed.xml")
+	if err != nil {
+		t.Fatalf("Fetch(url) error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("Fetch(url) items = %d, want 2", len(items))
+	}
+
+	if _, err := Fetch(t.Context(), server.Client(), server.URL+"/missing"); err == nil {
+		t.Error("Fetch() should error on non-200 responses")
+	}
+
+	path := filepath.Join(t.TempDir(), "atom.xml")
+	if err := os.WriteFile(path, []byte(atomFeed), 0600); err != nil {
+		t.Fatalf("Failed to write feed: %v", err)
+	}
+	items, err = Fetch(t.Context(), server.Client(), path)
+	if err != nil {
+		t.Fatalf("Fetch(file) error = %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Fetch(file) items = %d, want 1", len(items))
+	}
+}
+
+func TestSince(t *testing.T) {
+	now := time.Now()
+	items := []Item{
+		{Title: "old", Published: now.AddDate(0, 0, -60)},
+		{Title: "new", Published: now.AddDate(0, 0, -1)},
+		{Title: "undated"},
+	}
+
+	recent := Since(items, now.AddDate(0, 0, -30))
+	if len(recent) != 2 || recent[0].Title != "new" || recent[1].Title != "undated" {
+		t.Errorf("Since() = %+v, want new and undated", recent)
+	}
+}
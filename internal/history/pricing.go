@@ -0,0 +1,58 @@
+package history
+
+import (
+	"strings"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// DefaultPrices are Anthropic list prices in USD per million tokens, keyed
+// by model name prefix. The pricing section of config.yaml overrides them.
+var DefaultPrices = map[string]config.ModelPrice{
+	"claude-opus-4-5":   {Input: 5, Output: 25},
+	"claude-opus-4":     {Input: 15, Output: 75},
+	"claude-sonnet-4":   {Input: 3, Output: 15},
+	"claude-haiku-4-5":  {Input: 1, Output: 5},
+	"claude-3-7-sonnet": {Input: 3, Output: 15},
+	"claude-3-5-sonnet": {Input: 3, Output: 15},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4},
+	"claude-3-opus":     {Input: 15, Output: 75},
+	"claude-3-haiku":    {Input: 0.25, Output: 1.25},
+}
+
+// Price returns the price of a model: the configured price with the longest
+// matching prefix, or else the default one.
+func Price(model string, configured map[string]config.ModelPrice) (config.ModelPrice, bool) {
+	if price, ok := longestPrefix(model, configured); ok {
+		return price, true
+	}
+	return longestPrefix(model, DefaultPrices)
+}
+
+func longestPrefix(model string, prices map[string]config.ModelPrice) (config.ModelPrice, bool) {
+	var (
+		best  config.ModelPrice
+		match string
+		found bool
+	)
+	for prefix, price := range prices {
+		if strings.HasPrefix(model, prefix) && (!found || len(prefix) > len(match)) {
+			best, match, found = price, prefix, true
+		}
+	}
+	return best, found
+}
+
+// Cost returns what generating an article cost in USD. ok is false when
+// its token usage was not recorded or its model has no known price.
+func Cost(a storage.ArticleRecord, configured map[string]config.ModelPrice) (cost float64, ok bool) {
+	if a.Usage == nil {
+		return 0, false
+	}
+	price, ok := Price(a.Model, configured)
+	if !ok {
+		return 0, false
+	}
+	return (float64(a.Usage.InputTokens)*price.Input + float64(a.Usage.OutputTokens)*price.Output) / 1e6, true
+}
@@ -0,0 +1,48 @@
+package history
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestPrice(t *testing.T) {
+	configured := map[string]config.ModelPrice{"claude-sonnet-4-2025": {Input: 1, Output: 2}}
+
+	tests := []struct {
+		model  string
+		want   config.ModelPrice
+		wantOK bool
+	}{
+		{"claude-sonnet-4-20250514", config.ModelPrice{Input: 1, Output: 2}, true}, // configured wins
+		{"claude-sonnet-4-5", config.ModelPrice{Input: 3, Output: 15}, true},
+		{"claude-opus-4-5-20251101", config.ModelPrice{Input: 5, Output: 25}, true}, // longest prefix
+		{"claude-opus-4-1-20250805", config.ModelPrice{Input: 15, Output: 75}, true},
+		{"gpt-4o", config.ModelPrice{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := Price(tt.model, configured)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Price() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCost(t *testing.T) {
+	a := storage.ArticleRecord{Model: "claude-sonnet-4-20250514", Usage: &storage.Usage{InputTokens: 2000, OutputTokens: 4000}}
+	cost, ok := Cost(a, nil)
+	if want := 0.066; !ok || math.Abs(cost-want) > 1e-9 {
+		t.Errorf("Cost() = %v, %v, want %v", cost, ok, want)
+	}
+
+	if _, ok := Cost(storage.ArticleRecord{Model: "claude-sonnet-4-20250514"}, nil); ok {
+		t.Error("Cost() without usage should not be known")
+	}
+	if _, ok := Cost(storage.ArticleRecord{Model: "unknown", Usage: a.Usage}, nil); ok {
+		t.Error("Cost() of an unpriced model should not be known")
+	}
+}
@@ -0,0 +1,139 @@
+// Package history analyses the published article history.
+package history
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// Count is the number of articles with a topic, category or tag.
+type Count struct {
+	Name     string `json:"name"`
+	Articles int    `json:"articles"`
+}
+
+// Gap is the time between two consecutive publications.
+type Gap struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Days float64   `json:"days"`
+}
+
+// Stats summarises a set of published articles.
+type Stats struct {
+	Articles       int        `json:"articles"`
+	FirstPublished *time.Time `json:"first_published,omitempty"`
+	LastPublished  *time.Time `json:"last_published,omitempty"`
+
+	Topics     []Count `json:"topics"`
+	Categories []Count `json:"categories"` // Uncategorized articles count under ""
+	Tags       []Count `json:"tags"`       // Tags compared case-insensitively
+
+	AverageGapDays float64 `json:"average_gap_days"`
+	DaysSinceLast  float64 `json:"days_since_last"`
+	LongestGaps    []Gap   `json:"longest_gaps"`
+
+	AverageWords   float64 `json:"average_words"`    // Over articles with a recorded word count
+	PricedArticles int     `json:"priced_articles"`  // Articles with recorded usage and a known model price
+	TotalCost      float64 `json:"total_cost_usd"`   // Over priced articles
+	AverageCost    float64 `json:"average_cost_usd"` // Over priced articles
+}
+
+// Compute summarises articles as of now, listing up to gaps of the longest
+// publishing gaps; a negative gaps lists none.
+func Compute(articles []storage.ArticleRecord, prices map[string]config.ModelPrice, now time.Time, gaps int) Stats {
+	stats := Stats{Articles: len(articles)}
+	if len(articles) == 0 {
+		return stats
+	}
+
+	sorted := slices.Clone(articles)
+	slices.SortStableFunc(sorted, func(a, b storage.ArticleRecord) int {
+		return a.PublishedAt.Compare(b.PublishedAt)
+	})
+	first, last := sorted[0].PublishedAt, sorted[len(sorted)-1].PublishedAt
+	stats.FirstPublished, stats.LastPublished = &first, &last
+	stats.DaysSinceLast = days(now.Sub(last))
+
+	topics := newCounter()
+	categories := newCounter()
+	tags := newCounter()
+	var words, counted int
+	for _, a := range sorted {
+		topics.add(a.Topic)
+		categories.add(a.Category)
+		for _, tag := range a.Tags {
+			tags.add(tag)
+		}
+		if a.WordCount > 0 {
+			words += a.WordCount
+			counted++
+		}
+		if cost, ok := Cost(a, prices); ok {
+			stats.TotalCost += cost
+			stats.PricedArticles++
+		}
+	}
+	stats.Topics, stats.Categories, stats.Tags = topics.counts(), categories.counts(), tags.counts()
+	if counted > 0 {
+		stats.AverageWords = float64(words) / float64(counted)
+	}
+	if stats.PricedArticles > 0 {
+		stats.AverageCost = stats.TotalCost / float64(stats.PricedArticles)
+	}
+
+	var all []Gap
+	for i := 1; i < len(sorted); i++ {
+		from, to := sorted[i-1].PublishedAt, sorted[i].PublishedAt
+		all = append(all, Gap{From: from, To: to, Days: days(to.Sub(from))})
+	}
+	if len(all) > 0 {
+		stats.AverageGapDays = days(last.Sub(first)) / float64(len(all))
+	}
+	slices.SortStableFunc(all, func(a, b Gap) int {
+		return cmp.Compare(b.Days, a.Days)
+	})
+	stats.LongestGaps = all[:min(max(gaps, 0), len(all))]
+	return stats
+}
+
+func days(d time.Duration) float64 {
+	return d.Hours() / 24
+}
+
+// counter counts names case-insensitively, keeping the first spelling seen.
+type counter struct {
+	index map[string]int
+	list  []Count
+}
+
+func newCounter() *counter {
+	return &counter{index: make(map[string]int)}
+}
+
+func (c *counter) add(name string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	i, ok := c.index[key]
+	if !ok {
+		i = len(c.list)
+		c.index[key] = i
+		c.list = append(c.list, Count{Name: strings.TrimSpace(name)})
+	}
+	c.list[i].Articles++
+}
+
+// counts returns the counts, most frequent first, then by name.
+func (c *counter) counts() []Count {
+	slices.SortStableFunc(c.list, func(a, b Count) int {
+		if n := cmp.Compare(b.Articles, a.Articles); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return c.list
+}
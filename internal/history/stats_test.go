@@ -0,0 +1,65 @@
+package history
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestCompute(t *testing.T) {
+	day := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	usage := &storage.Usage{InputTokens: 1000, OutputTokens: 2000} // $0.033 with Sonnet 4
+	articles := []storage.ArticleRecord{
+		{Topic: "Go", Category: "Lang", PublishedAt: day.AddDate(0, 0, 10), Tags: []string{"go", "testing"}, WordCount: 1000, Model: "claude-sonnet-4-20250514", Usage: usage},
+		{Topic: "Go", Category: "Lang", PublishedAt: day, Tags: []string{"Go"}, WordCount: 2000, Model: "claude-sonnet-4-20250514", Usage: usage},
+		{Topic: "AI", PublishedAt: day.AddDate(0, 0, 12), Tags: []string{"ml"}},
+	}
+
+	stats := Compute(articles, nil, day.AddDate(0, 0, 15), 1)
+
+	if stats.Articles != 3 || !stats.FirstPublished.Equal(day) || !stats.LastPublished.Equal(day.AddDate(0, 0, 12)) {
+		t.Errorf("Articles = %d, first %v, last %v", stats.Articles, stats.FirstPublished, stats.LastPublished)
+	}
+	if want := []Count{{"Go", 2}, {"AI", 1}}; !reflect.DeepEqual(stats.Topics, want) {
+		t.Errorf("Topics = %v, want %v", stats.Topics, want)
+	}
+	if want := []Count{{"Lang", 2}, {"", 1}}; !reflect.DeepEqual(stats.Categories, want) {
+		t.Errorf("Categories = %v, want %v", stats.Categories, want)
+	}
+	// Tags are counted case-insensitively under their first spelling
+	if want := []Count{{"Go", 2}, {"ml", 1}, {"testing", 1}}; !reflect.DeepEqual(stats.Tags, want) {
+		t.Errorf("Tags = %v, want %v", stats.Tags, want)
+	}
+
+	if stats.AverageGapDays != 6 || stats.DaysSinceLast != 3 {
+		t.Errorf("AverageGapDays = %v, DaysSinceLast = %v, want 6 and 3", stats.AverageGapDays, stats.DaysSinceLast)
+	}
+	if want := []Gap{{From: day, To: day.AddDate(0, 0, 10), Days: 10}}; !reflect.DeepEqual(stats.LongestGaps, want) {
+		t.Errorf("LongestGaps = %v, want %v", stats.LongestGaps, want)
+	}
+
+	if stats.AverageWords != 1500 {
+		t.Errorf("AverageWords = %v, want 1500 (unrecorded counts ignored)", stats.AverageWords)
+	}
+	if stats.PricedArticles != 2 || math.Abs(stats.TotalCost-0.066) > 1e-9 || math.Abs(stats.AverageCost-0.033) > 1e-9 {
+		t.Errorf("cost = %d priced, total %v, average %v", stats.PricedArticles, stats.TotalCost, stats.AverageCost)
+	}
+}
+
+func TestCompute_NegativeGaps(t *testing.T) {
+	day := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	articles := []storage.ArticleRecord{{Topic: "Go", PublishedAt: day}, {Topic: "Go", PublishedAt: day.AddDate(0, 0, 7)}}
+	if stats := Compute(articles, nil, day.AddDate(0, 0, 10), -1); len(stats.LongestGaps) != 0 {
+		t.Errorf("Compute() with -1 gaps = %+v, want none", stats.LongestGaps)
+	}
+}
+
+func TestCompute_Empty(t *testing.T) {
+	stats := Compute(nil, nil, time.Now(), 3)
+	if stats.Articles != 0 || stats.FirstPublished != nil || stats.LongestGaps != nil {
+		t.Errorf("Compute(nil) = %+v, want zero stats", stats)
+	}
+}
@@ -0,0 +1,147 @@
+package medium
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// ExportedPost is a published post read from a Medium account export.
+type ExportedPost struct {
+	File         string // Name of the post in the archive
+	Title        string
+	Subtitle     string
+	PublishedAt  time.Time
+	CanonicalURL string
+	Tags         []string
+	Markdown     string // Body converted to Markdown
+}
+
+// ReadExport reads the published posts of a Medium export zip, oldest
+// first. Drafts are skipped, as are posts without a publication date.
+func ReadExport(zipPath string) ([]ExportedPost, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Medium export: %w", err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	var posts []ExportedPost
+	for _, f := range r.File {
+		name := path.Base(f.Name)
+		if path.Base(path.Dir(f.Name)) != "posts" || !strings.HasSuffix(name, ".html") || strings.HasPrefix(name, "draft_") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		post, err := ParseExportedPost(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if post.PublishedAt.IsZero() {
+			continue
+		}
+		post.File = f.Name
+		posts = append(posts, post)
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].PublishedAt.Before(posts[j].PublishedAt)
+	})
+	return posts, nil
+}
+
+// ParseExportedPost reads one post of a Medium export. Medium marks up
+// posts with h-entry microformats: p-name, p-summary, e-content,
+// dt-published, p-canonical and p-category.
+func ParseExportedPost(r io.Reader) (ExportedPost, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return ExportedPost{}, err
+	}
+
+	var post ExportedPost
+	if n := find(doc, func(n *html.Node) bool { return hasClass(n, "p-name") }); n != nil {
+		post.Title = text(n)
+	}
+	if post.Title == "" {
+		if n := find(doc, func(n *html.Node) bool { return n.Data == "title" }); n != nil {
+			post.Title = text(n)
+		}
+	}
+	if post.Title == "" {
+		return ExportedPost{}, fmt.Errorf("post has no title")
+	}
+
+	if n := find(doc, func(n *html.Node) bool { return attr(n, "data-field") == "subtitle" }); n != nil {
+		post.Subtitle = text(n)
+	}
+	if n := find(doc, func(n *html.Node) bool { return hasClass(n, "e-content") || attr(n, "data-field") == "body" }); n != nil {
+		post.Markdown = ToMarkdown(n)
+	}
+	if n := find(doc, func(n *html.Node) bool { return hasClass(n, "dt-published") }); n != nil {
+		if post.PublishedAt, err = time.Parse(time.RFC3339, attr(n, "datetime")); err != nil {
+			return ExportedPost{}, fmt.Errorf("invalid publication date: %w", err)
+		}
+	}
+	if n := find(doc, func(n *html.Node) bool { return hasClass(n, "p-canonical") }); n != nil {
+		post.CanonicalURL = attr(n, "href")
+	}
+	walk(doc, func(n *html.Node) {
+		if hasClass(n, "p-category") {
+			if tag := text(n); tag != "" {
+				post.Tags = append(post.Tags, tag)
+			}
+		}
+	})
+	return post, nil
+}
+
+// find returns the first element in document order satisfying match.
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := find(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// walk calls fn for every element under n.
+func walk(n *html.Node, fn func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		fn(n)
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		walk(child, fn)
+	}
+}
+
+// text returns the whitespace-collapsed text content of n.
+func text(n *html.Node) string {
+	var b strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
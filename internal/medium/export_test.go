@@ -0,0 +1,123 @@
+package medium
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exportedPostHTML is a post as Medium writes it to an account export.
+const exportedPostHTML = `<!DOCTYPE html><html><head><meta charset="utf-8"><title>Goroutines in Practice</title></head><body>
+<article class="h-entry">
+<header><h1 class="p-name">Goroutines in Practice</h1></header>
+<section data-field="subtitle" class="p-summary">What I learned running them in production</section>
+<section data-field="body" class="e-content">
+<section name="abc" class="section section--body section--first"><div class="section-divider"><hr class="section-divider"></div>
+<div class="section-content"><div class="section-inner sectionLayout--insetColumn">
+<h3 name="t1" class="graf graf--h3 graf--leading graf--title">Goroutines in Practice</h3>
+<p name="p1" class="graf graf--p">Start with <strong class="markup--strong">channels</strong>.</p>
+</div></div></section>
+</section>
+<footer><p>By <a href="https://medium.com/@someone" class="p-author h-card">Someone</a> on <a href="https://medium.com/p/abc"><time class="dt-published" datetime="2023-03-14T09:30:00.123Z">March 14, 2023</time></a>.</p>
+<p><a href="https://medium.com/@someone/goroutines-in-practice-abc" class="p-canonical">Canonical link</a></p>
+<ul><li><a class="p-category" href="https://medium.com/tag/golang">Golang</a></li><li><a class="p-category" href="https://medium.com/tag/concurrency">Concurrency</a></li></ul>
+</footer></article></body></html>`
+
+func TestParseExportedPost(t *testing.T) {
+	post, err := ParseExportedPost(strings.NewReader(exportedPostHTML))
+	if err != nil {
+		t.Fatalf("ParseExportedPost() error = %v", err)
+	}
+
+	want := ExportedPost{
+		Title:        "Goroutines in Practice",
+		Subtitle:     "What I learned running them in production",
+		PublishedAt:  time.Date(2023, 3, 14, 9, 30, 0, 123000000, time.UTC),
+		CanonicalURL: "https://medium.com/@someone/goroutines-in-practice-abc",
+		Tags:         []string{"Golang", "Concurrency"},
+		Markdown:     "Start with **channels**.",
+	}
+	if !reflect.DeepEqual(post, want) {
+		t.Errorf("ParseExportedPost() = %+v, want %+v", post, want)
+	}
+}
+
+func TestParseExportedPost_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+	}{
+		{"no title", `<html><body><p>Nothing</p></body></html>`},
+		{"invalid date", `<h1 class="p-name">T</h1><time class="dt-published" datetime="yesterday"></time>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseExportedPost(strings.NewReader(tt.html)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestReadExport(t *testing.T) {
+	older := strings.Replace(strings.ReplaceAll(exportedPostHTML, "Goroutines in Practice", "Older Post"),
+		"2023-03-14T09:30:00.123Z", "2021-01-02T10:00:00Z", 1)
+	files := map[string]string{
+		"medium-export/posts/2023-03-14_Goroutines-in-Practice-abc.html": exportedPostHTML,
+		"medium-export/posts/2021-01-02_Older-Post-def.html":             older,
+		"medium-export/posts/draft_Unfinished-123.html":                  `<h1 class="p-name">Unfinished</h1>`,
+		"medium-export/posts/2022-05-05_Unpublished-456.html":            `<h1 class="p-name">Unpublished</h1>`,
+		"medium-export/profile/profile.html":                             `<h1 class="p-name">Profile</h1>`,
+	}
+
+	path := filepath.Join(t.TempDir(), "medium-export.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	posts, err := ReadExport(path)
+	if err != nil {
+		t.Fatalf("ReadExport() error = %v", err)
+	}
+	var titles []string
+	for _, p := range posts {
+		titles = append(titles, p.Title)
+	}
+	if want := []string{"Older Post", "Goroutines in Practice"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("titles = %v, want %v", titles, want)
+	}
+	if posts[0].File != "medium-export/posts/2021-01-02_Older-Post-def.html" {
+		t.Errorf("File = %q", posts[0].File)
+	}
+}
+
+func TestReadExport_NotAZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.zip")
+	if err := os.WriteFile(path, []byte("not a zip"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadExport(path); err == nil {
+		t.Error("expected error")
+	}
+}
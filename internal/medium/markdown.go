@@ -0,0 +1,190 @@
+package medium
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blankLines matches runs of blank lines, collapsed to one.
+var blankLines = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+
+// ToMarkdown converts the HTML of a Medium post body to Markdown. Medium's
+// repeated title heading and section dividers are dropped.
+func ToMarkdown(n *html.Node) string {
+	var c converter
+	md := blankLines.ReplaceAllString(c.children(n), "\n\n")
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// converter renders HTML nodes as Markdown.
+type converter struct {
+	pre bool // Inside a code block: keep text verbatim
+}
+
+func (c *converter) children(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.node(child))
+	}
+	return b.String()
+}
+
+func (c *converter) node(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		if c.pre {
+			return n.Data
+		}
+		return collapseSpace(n.Data)
+	case html.ElementNode:
+	default:
+		return c.children(n)
+	}
+
+	if hasClass(n, "graf--title") || hasClass(n, "section-divider") {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Title, atom.Iframe:
+		return ""
+	case atom.H1:
+		return block("# " + c.inline(n))
+	case atom.H2, atom.H3:
+		return block("## " + c.inline(n))
+	case atom.H4, atom.H5, atom.H6:
+		return block("### " + c.inline(n))
+	case atom.P, atom.Figcaption:
+		text := c.inline(n)
+		if n.DataAtom == atom.Figcaption && text != "" {
+			text = "*" + text + "*"
+		}
+		return block(text)
+	case atom.Br:
+		if c.pre {
+			return "\n"
+		}
+		return "  \n"
+	case atom.Hr:
+		return block("---")
+	case atom.Strong, atom.B:
+		return wrap("**", c.children(n))
+	case atom.Em, atom.I:
+		return wrap("*", c.children(n))
+	case atom.Code:
+		if c.pre {
+			return c.children(n)
+		}
+		return wrap("`", c.children(n))
+	case atom.Pre:
+		c.pre = true
+		code := strings.Trim(c.children(n), "\n")
+		c.pre = false
+		return block("```\n" + code + "\n```")
+	case atom.A:
+		text := strings.TrimSpace(c.children(n))
+		href := attr(n, "href")
+		if text == "" || href == "" || c.pre {
+			return text
+		}
+		return "[" + text + "](" + href + ")"
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + attr(n, "alt") + "](" + src + ")"
+	case atom.Blockquote:
+		var quoted []string
+		for _, line := range strings.Split(strings.TrimSpace(blankLines.ReplaceAllString(c.children(n), "\n\n")), "\n") {
+			quoted = append(quoted, strings.TrimRight("> "+strings.TrimSpace(line), " "))
+		}
+		return block(strings.Join(quoted, "\n"))
+	case atom.Ul, atom.Ol:
+		var items []string
+		for li := n.FirstChild; li != nil; li = li.NextSibling {
+			if li.DataAtom != atom.Li {
+				continue
+			}
+			marker := "- "
+			if n.DataAtom == atom.Ol {
+				marker = strconv.Itoa(len(items)+1) + ". "
+			}
+			items = append(items, marker+c.inline(li))
+		}
+		return block(strings.Join(items, "\n"))
+	case atom.Figure, atom.Div, atom.Section, atom.Article:
+		return "\n\n" + c.children(n) + "\n\n"
+	}
+	return c.children(n)
+}
+
+// inline renders the children of n on one trimmed line.
+func (c *converter) inline(n *html.Node) string {
+	return strings.TrimSpace(c.children(n))
+}
+
+// block surrounds text with blank lines, or drops it when empty.
+func block(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	return "\n\n" + text + "\n\n"
+}
+
+// wrap puts a marker around text, keeping surrounding spaces outside it so
+// "<em>word </em>" becomes "*word* ".
+func wrap(marker, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	trail := text[len(strings.TrimRight(text, " ")):]
+	return lead + marker + trimmed + marker + trail
+}
+
+// collapseSpace replaces runs of whitespace with one space, as browsers do.
+func collapseSpace(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s == "" {
+			return ""
+		}
+		return " "
+	}
+	out := strings.Join(fields, " ")
+	if strings.TrimLeft(s, " \t\r\n") != s {
+		out = " " + out
+	}
+	if strings.TrimRight(s, " \t\r\n") != s {
+		out += " "
+	}
+	return out
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package medium
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "drops repeated title",
+			html: `<h3 class="graf graf--h3 graf--title">Title</h3><p>Body text.</p>`,
+			want: "Body text.",
+		},
+		{
+			name: "inline formatting",
+			html: `<p>Use <strong>bold </strong>and <em>italic</em>, <code>go test</code> and <a href="https://go.dev">a link</a>.</p>`,
+			want: "Use **bold** and *italic*, `go test` and [a link](https://go.dev).",
+		},
+		{
+			name: "headings",
+			html: `<h3>Section</h3><h4>Subsection</h4><p>Text</p>`,
+			want: "## Section\n\n### Subsection\n\nText",
+		},
+		{
+			name: "code block keeps line breaks",
+			html: `<pre class="graf graf--pre">func main() {<br>    fmt.Println("hi")<br>}</pre>`,
+			want: "```\nfunc main() {\n    fmt.Println(\"hi\")\n}\n```",
+		},
+		{
+			name: "lists",
+			html: `<ul><li>one</li><li>two</li></ul><ol><li>first</li><li>second</li></ol>`,
+			want: "- one\n- two\n\n1. first\n2. second",
+		},
+		{
+			name: "blockquote",
+			html: `<blockquote><p>Quoted</p><p>Twice</p></blockquote>`,
+			want: "> Quoted\n>\n> Twice",
+		},
+		{
+			name: "figure with caption",
+			html: `<figure><img src="https://cdn-images-1.medium.com/x.png" alt="Diagram"><figcaption>How it fits</figcaption></figure>`,
+			want: "![Diagram](https://cdn-images-1.medium.com/x.png)\n\n*How it fits*",
+		},
+		{
+			name: "section divider",
+			html: `<p>Before</p><div class="section-divider"><hr class="section-divider"></div><p>After</p>`,
+			want: "Before\n\nAfter",
+		},
+		{
+			name: "collapses whitespace",
+			html: "<p>Lots   of\n\n   space</p>",
+			want: "Lots of space",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ToMarkdown(doc); got != tt.want {
+				t.Errorf("ToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,26 +5,46 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/yourusername/autoblog-ai/internal/article"
 )
 
+// ErrNotPublished matches Publish errors after which the article certainly
+// was not published: the post was never sent, or Medium refused it. Other
+// errors, such as a connection lost mid-request, leave the outcome unknown.
+var ErrNotPublished = errors.New("article was not published")
+
+// notPublished marks an error as matching ErrNotPublished, keeping its message.
+type notPublished struct {
+	err error
+}
+
+func (e notPublished) Error() string   { return e.err.Error() }
+func (e notPublished) Unwrap() []error { return []error{e.err, ErrNotPublished} }
+
 // Publisher is an interface for publishing articles to Medium.
 type Publisher interface {
 	Publish(ctx context.Context, article *article.Article) (string, error)
+	// FindPost looks for a recent post with the given title and returns
+	// its URL, to learn whether an interrupted publish went through.
+	FindPost(ctx context.Context, title string) (url string, found bool, err error)
 }
 
 // mediumPublisher is the concrete implementation of Publisher.
 type mediumPublisher struct {
-	token  string
-	client *http.Client
-	apiURL string
-	logger *slog.Logger
+	token   string
+	client  *http.Client
+	apiURL  string
+	feedURL string // RSS feeds of users' latest posts
+	logger  *slog.Logger
 }
 
 // User represents a Medium user account.
@@ -47,20 +67,22 @@ type Post struct {
 func NewPublisher(token string) Publisher {
 	logger := slog.Default().With("component", "medium.publisher")
 	return &mediumPublisher{
-		token:  token,
-		client: &http.Client{Timeout: 30 * time.Second},
-		apiURL: "https://api.medium.com/v1",
-		logger: logger,
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		apiURL:  "https://api.medium.com/v1",
+		feedURL: "https://medium.com/feed",
+		logger:  logger,
 	}
 }
 
 // NewPublisherWithLogger creates a new Medium publisher with a custom logger.
 func NewPublisherWithLogger(token string, logger *slog.Logger) Publisher {
 	return &mediumPublisher{
-		token:  token,
-		client: &http.Client{Timeout: 30 * time.Second},
-		apiURL: "https://api.medium.com/v1",
-		logger: logger.With("component", "medium.publisher"),
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		apiURL:  "https://api.medium.com/v1",
+		feedURL: "https://medium.com/feed",
+		logger:  logger.With("component", "medium.publisher"),
 	}
 }
 
@@ -78,7 +100,7 @@ func (p *mediumPublisher) Publish(ctx context.Context, article *article.Article)
 	user, err := p.getUser(ctx)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to get Medium user", "error", err)
-		return "", fmt.Errorf("failed to get user: %w", err)
+		return "", notPublished{fmt.Errorf("failed to get user: %w", err)}
 	}
 
 	logger.InfoContext(ctx, "Successfully retrieved user information",
@@ -99,13 +121,13 @@ func (p *mediumPublisher) Publish(ctx context.Context, article *article.Article)
 	jsonData, err := json.Marshal(post)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to marshal post data", "error", err)
-		return "", err
+		return "", notPublished{err}
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to create HTTP request", "error", err)
-		return "", err
+		return "", notPublished{err}
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.token))
@@ -141,7 +163,7 @@ func (p *mediumPublisher) Publish(ctx context.Context, article *article.Article)
 		logger.ErrorContext(ctx, "Publication failed",
 			"status_code", resp.StatusCode,
 			"response_body", string(body))
-		return "", fmt.Errorf("failed to publish (status %d): %s", resp.StatusCode, string(body))
+		return "", notPublished{fmt.Errorf("failed to publish (status %d): %s", resp.StatusCode, string(body))}
 	}
 
 	var result struct {
@@ -219,4 +241,52 @@ func (p *mediumPublisher) getUser(ctx context.Context) (*User, error) {
 	return &result.Data, nil
 }
 
+// FindPost looks for a post with the given title, compared
+// case-insensitively, in the user's RSS feed. Medium's API cannot list posts
+// and the feed only holds the ten latest, which covers a publish that was
+// interrupted on the last run.
+func (p *mediumPublisher) FindPost(ctx context.Context, title string) (string, bool, error) {
+	user, err := p.getUser(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	feedURL := fmt.Sprintf("%s/@%s", p.feedURL, user.Username)
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Accept", "application/rss+xml")
+
+	p.logger.DebugContext(ctx, "Fetching user feed", "feed_url", feedURL)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("failed to fetch %s (status %d)", feedURL, resp.StatusCode)
+	}
+
+	var feed struct {
+		Items []struct {
+			Title string `xml:"title"`
+			Link  string `xml:"link"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return "", false, fmt.Errorf("failed to parse %s: %w", feedURL, err)
+	}
+	for _, item := range feed.Items {
+		if strings.EqualFold(strings.TrimSpace(item.Title), strings.TrimSpace(title)) {
+			// Drop the ?source=rss-… tracking parameter
+			url, _, _ := strings.Cut(strings.TrimSpace(item.Link), "?")
+			return url, true, nil
+		}
+	}
+	return "", false, nil
+}
+
 var _ Publisher = &mediumPublisher{}
@@ -3,6 +3,7 @@ package medium
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -17,10 +18,11 @@ import (
 func newTestPublisher(token, apiURL string) Publisher {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	return &mediumPublisher{
-		token:  token,
-		client: &http.Client{Timeout: 30 * time.Second},
-		apiURL: apiURL,
-		logger: logger,
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		apiURL:  apiURL,
+		feedURL: apiURL + "/feed",
+		logger:  logger,
 	}
 }
 
@@ -385,3 +387,115 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFindPost(t *testing.T) {
+	feed := `<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel>
+<title>Stories by Test User on Medium</title>
+<item><title><![CDATA[Other Post]]></title><link>https://medium.com/@testuser/other-post-1?source=rss-abc</link></item>
+<item><title><![CDATA[Go Channels Explained]]></title><link>https://medium.com/@testuser/go-channels-explained-2?source=rss-abc</link></item>
+</channel></rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"id":"test-user-id","username":"testuser"}}`))
+		case "/feed/@testuser":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			_, _ = w.Write([]byte(feed))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	pub := newTestPublisher("test-token", server.URL)
+
+	tests := []struct {
+		title     string
+		wantURL   string
+		wantFound bool
+	}{
+		{"go channels explained", "https://medium.com/@testuser/go-channels-explained-2", true},
+		{"Unpublished", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			url, found, err := pub.FindPost(context.Background(), tt.title)
+			if err != nil {
+				t.Fatalf("FindPost() error = %v", err)
+			}
+			if url != tt.wantURL || found != tt.wantFound {
+				t.Errorf("FindPost() = %q, %v; want %q, %v", url, found, tt.wantURL, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestFindPost_FeedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/me" {
+			_, _ = w.Write([]byte(`{"data":{"id":"test-user-id","username":"testuser"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, _, err := newTestPublisher("test-token", server.URL).FindPost(context.Background(), "Any"); err == nil {
+		t.Error("FindPost() should fail when the feed is unavailable")
+	}
+}
+
+func TestPublish_ErrNotPublished(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    bool
+	}{
+		{
+			name: "user lookup fails",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			want: true,
+		},
+		{
+			name: "post refused",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/me" {
+					_, _ = w.Write([]byte(`{"data":{"id":"test-user-id","username":"testuser"}}`))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			want: true,
+		},
+		{
+			name: "connection lost after sending",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/me" {
+					_, _ = w.Write([]byte(`{"data":{"id":"test-user-id","username":"testuser"}}`))
+					return
+				}
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					_ = conn.Close()
+				}
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			_, err := newTestPublisher("test-token", server.URL).Publish(context.Background(), &article.Article{Title: "T", Content: "C"})
+			if err == nil {
+				t.Fatal("Publish() error = nil")
+			}
+			if got := errors.Is(err, ErrNotPublished); got != tt.want {
+				t.Errorf("errors.Is(%v, ErrNotPublished) = %v, want %v", err, got, tt.want)
+			}
+		})
+	}
+}
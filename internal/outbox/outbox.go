@@ -0,0 +1,103 @@
+// Package outbox keeps articles that failed to publish, so they can be
+// published later without generating them again.
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// Entry is an article waiting to be published.
+type Entry struct {
+	ID       string           `json:"id"`
+	Topic    string           `json:"topic"`
+	Article  *article.Article `json:"article"`
+	Error    string           `json:"error"` // Last publish failure
+	FailedAt time.Time        `json:"failed_at"`
+	Attempts int              `json:"attempts"`
+}
+
+// Outbox stores entries as JSON files in a directory, one per article.
+type Outbox struct {
+	dir string
+}
+
+// New returns the outbox kept in dir.
+func New(dir string) *Outbox {
+	return &Outbox{dir: dir}
+}
+
+// Add saves an entry, replacing an earlier one with the same ID, and
+// returns its path. An empty ID is filled in with storage.RecordID.
+func (o *Outbox) Add(e Entry) (string, error) {
+	if e.Article == nil {
+		return "", fmt.Errorf("outbox entry has no article")
+	}
+	if e.ID == "" {
+		e.ID = storage.RecordID(e.Article.Title, e.Article.PublishedAt)
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	// #nosec G301 -- 0755 is appropriate for output directory
+	if err := os.MkdirAll(o.dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create outbox: %w", err)
+	}
+	path := o.path(e.ID)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// List returns the entries, oldest failure first. A missing outbox is empty.
+func (o *Outbox) List() ([]Entry, error) {
+	files, err := filepath.Glob(filepath.Join(o.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		// #nosec G304 -- files are listed from the outbox directory
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if e.Article == nil {
+			return nil, fmt.Errorf("%s: entry has no article", file)
+		}
+		if e.ID == "" {
+			e.ID = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+		entries = append(entries, e)
+	}
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return a.FailedAt.Compare(b.FailedAt)
+	})
+	return entries, nil
+}
+
+// Remove deletes an entry once its article is published.
+func (o *Outbox) Remove(id string) error {
+	if err := os.Remove(o.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (o *Outbox) path(id string) string {
+	return filepath.Join(o.dir, id+".json")
+}
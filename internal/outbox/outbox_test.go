@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestOutbox(t *testing.T) {
+	box := New(filepath.Join(t.TempDir(), "outbox"))
+
+	entries, err := box.List()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("List() of a missing outbox = %v, %v; want empty", entries, err)
+	}
+
+	day := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	newer := Entry{
+		Topic: "Go",
+		Article: &article.Article{
+			Title:       "Go Channels",
+			Content:     "Body",
+			Tags:        []string{"go"},
+			PublishedAt: day,
+			Model:       "claude-sonnet-4-20250514",
+			Usage:       storage.Usage{InputTokens: 10, OutputTokens: 20},
+		},
+		Error:    "status 503",
+		FailedAt: day.Add(time.Hour),
+		Attempts: 1,
+	}
+	older := Entry{ID: "older", Article: &article.Article{Title: "Older", Content: "Body"}, FailedAt: day}
+
+	path, err := box.Add(newer)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	newer.ID = storage.RecordID("Go Channels", day)
+	if filepath.Base(path) != newer.ID+".json" {
+		t.Errorf("Add() path = %s, want the record ID", path)
+	}
+	if _, err := box.Add(older); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	entries, err = box.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []Entry{older, newer}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("List() = %+v, want %+v", entries, want)
+	}
+
+	// Adding again replaces the entry
+	newer.Attempts = 2
+	if _, err := box.Add(newer); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if entries, _ := box.List(); len(entries) != 2 || entries[1].Attempts != 2 {
+		t.Errorf("List() after re-adding = %+v", entries)
+	}
+
+	if err := box.Remove(older.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := box.Remove(older.ID); err != nil {
+		t.Errorf("Remove() of a removed entry error = %v", err)
+	}
+	if entries, _ := box.List(); len(entries) != 1 || entries[0].ID != newer.ID {
+		t.Errorf("List() after Remove() = %+v", entries)
+	}
+}
+
+func TestOutbox_Errors(t *testing.T) {
+	dir := t.TempDir()
+	box := New(dir)
+	if _, err := box.Add(Entry{}); err == nil {
+		t.Error("Add() without an article should fail")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := box.List(); err == nil {
+		t.Error("List() with a corrupt entry should fail")
+	}
+}
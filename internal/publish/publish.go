@@ -0,0 +1,238 @@
+// Package publish publishes articles at most once and records every
+// publication. Before an article is sent to the publisher, a write-ahead
+// record marks it as publishing; if the run dies before the result is
+// saved, Reconcile settles the record on the next run instead of the
+// article being published again.
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/medium"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// ReconcileAfter is how long a publish may be in flight before Reconcile
+// treats it as interrupted rather than still running in another process.
+const ReconcileAfter = 15 * time.Minute
+
+var (
+	// ErrAlreadyPublished is returned for content whose hash is recorded
+	// as published, or a record that is already published.
+	ErrAlreadyPublished = errors.New("already published")
+	// ErrInFlight is returned when the same article or content is being
+	// published, or its last publish was interrupted and not reconciled.
+	ErrInFlight = errors.New("publish already in flight")
+	// ErrNotRecorded is returned when the article was published but its
+	// record could not be saved; Reconcile completes it on the next run.
+	ErrNotRecorded = errors.New("published, but not recorded")
+)
+
+// Publisher publishes articles and finds recently published ones by title.
+// medium.Publisher satisfies it.
+type Publisher interface {
+	Publish(ctx context.Context, article *article.Article) (string, error)
+	FindPost(ctx context.Context, title string) (url string, found bool, err error)
+}
+
+// Option changes how Publish handles an article.
+type Option func(*options)
+
+// options holds the settings collected from Option values.
+type options struct {
+	onLive func(storage.ArticleRecord)
+}
+
+// OnLive calls fn with the published record once the article is live, just
+// before the record is saved. Files committed along with the history, such
+// as a saved copy of the article, can be updated to match.
+func OnLive(fn func(record storage.ArticleRecord)) Option {
+	return func(o *options) {
+		o.onLive = fn
+	}
+}
+
+// Publish publishes an article exactly once and records it in the history.
+// The record's ID is the idempotency key: retries of the same article must
+// reuse it. A record already stored under that ID, such as an approved
+// draft, is replaced; its previous state is restored if publishing fails.
+// The content is archived with the write-ahead record, so an interrupted
+// publish can be retried from the archive.
+func Publish(ctx context.Context, store storage.Store, archive storage.ContentArchive, publisher Publisher, record storage.ArticleRecord, content string, opts ...Option) (storage.ArticleRecord, error) {
+	if record.ID == "" {
+		return record, fmt.Errorf("article %q has no ID", record.Title)
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	// Write ahead: refuse, or archive the content and mark the article as
+	// publishing. Archiving only once the checks pass keeps the content of
+	// a published or in-flight article intact.
+	hash := storage.ContentHash([]byte(content))
+	record.Status, record.ReviewNote = storage.StatusPublishing, ""
+	record.PublishedAt = time.Now()
+	var previous *storage.ArticleRecord
+	err := store.Update(func(history *storage.ArticleHistory) error {
+		previous = nil // Update may retry
+		for _, a := range history.Articles {
+			if a.ID == record.ID {
+				if err := check(a, "article"); err != nil {
+					return err
+				}
+				prev := a
+				previous = &prev
+			} else if a.ContentHash == hash {
+				if err := check(a, "content"); err != nil {
+					return err
+				}
+			}
+		}
+		if err := archive.Put(&record, content); err != nil {
+			return fmt.Errorf("failed to archive content: %w", err)
+		}
+		replace(history, record)
+		return nil
+	})
+	if err != nil {
+		return record, err
+	}
+
+	url, err := publisher.Publish(ctx, &article.Article{Title: record.Title, Content: content, Tags: record.Tags})
+	if err != nil {
+		// Unless the publisher says otherwise, the request may have failed
+		// after the post was created
+		var (
+			found   bool
+			findErr error
+		)
+		if !errors.Is(err, medium.ErrNotPublished) {
+			url, found, findErr = publisher.FindPost(ctx, record.Title)
+		}
+		switch {
+		case findErr != nil:
+			return record, fmt.Errorf("%w (whether it went through is unknown; the next run will reconcile it: %v)", err, findErr)
+		case found:
+			slog.Warn("Publish reported an error but the post exists", "id", record.ID, "url", url, "error", err)
+		default:
+			if undoErr := undo(store, record.ID, previous); undoErr != nil {
+				return record, fmt.Errorf("%w (and the in-flight record could not be cleared: %v)", err, undoErr)
+			}
+			return record, err
+		}
+	}
+
+	record.Status, record.URL = "", url
+	if o.onLive != nil {
+		o.onLive(record)
+	}
+	if err := store.Update(func(history *storage.ArticleHistory) error {
+		replace(history, record)
+		return nil
+	}); err != nil {
+		return record, fmt.Errorf("%w: %s is live at %s, the next run will record it: %v", ErrNotRecorded, record.ID, url, err)
+	}
+	// The s3 archive keeps a copy of the record next to the content
+	if err := archive.Put(&record, content); err != nil {
+		slog.Warn("Could not refresh archived record", "id", record.ID, "error", err)
+	}
+	return record, nil
+}
+
+// check refuses to publish over a published or in-flight record.
+func check(a storage.ArticleRecord, what string) error {
+	switch {
+	case a.Published():
+		return fmt.Errorf("%w: %s matches %s, published at %s", ErrAlreadyPublished, what, a.ID, a.URL)
+	case a.Status == storage.StatusPublishing:
+		return fmt.Errorf("%w: %s matches %s, publishing since %s", ErrInFlight, what, a.ID, a.PublishedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// replace stores record in place of the record with the same ID, or
+// appends it.
+func replace(history *storage.ArticleHistory, record storage.ArticleRecord) {
+	for i := range history.Articles {
+		if history.Articles[i].ID == record.ID {
+			history.Articles[i] = record
+			return
+		}
+	}
+	history.Articles = append(history.Articles, record)
+}
+
+// undo restores the record a failed publish replaced, or removes the
+// write-ahead record of a new article.
+func undo(store storage.Store, id string, previous *storage.ArticleRecord) error {
+	return store.Update(func(history *storage.ArticleHistory) error {
+		for i, a := range history.Articles {
+			if a.ID != id || a.Status != storage.StatusPublishing {
+				continue
+			}
+			if previous != nil {
+				history.Articles[i] = *previous
+			} else {
+				history.Articles = append(history.Articles[:i], history.Articles[i+1:]...)
+			}
+			break
+		}
+		return nil
+	})
+}
+
+// Reconcile settles publishes left in flight by an interrupted run. An
+// article found on the publisher is recorded as published. One that is
+// not found, once in flight for ReconcileAfter, becomes an approved draft
+// so "publish --approved" can retry it from the archive. Articles that
+// cannot be looked up stay in flight, and keep blocking republication.
+func Reconcile(ctx context.Context, store storage.Store, publisher Publisher) error {
+	inFlight, err := store.Query(storage.Query{Status: storage.StatusPublishing})
+	if err != nil {
+		return err
+	}
+
+	type outcome struct {
+		url   string
+		found bool
+	}
+	outcomes := make(map[string]outcome)
+	for _, a := range inFlight {
+		url, found, err := publisher.FindPost(ctx, a.Title)
+		switch {
+		case err != nil:
+			slog.Warn("Could not check interrupted publish", "id", a.ID, "title", a.Title, "error", err)
+		case !found && time.Since(a.PublishedAt) < ReconcileAfter:
+			slog.Info("Publish still in flight", "id", a.ID, "title", a.Title, "since", a.PublishedAt)
+		default:
+			outcomes[a.ID] = outcome{url: url, found: found}
+		}
+	}
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	return store.Update(func(history *storage.ArticleHistory) error {
+		for i := range history.Articles {
+			a := &history.Articles[i]
+			o, ok := outcomes[a.ID]
+			if !ok || a.Status != storage.StatusPublishing {
+				continue
+			}
+			if o.found {
+				a.Status, a.URL = "", o.url
+				slog.Info("Recorded interrupted publish", "id", a.ID, "url", o.url)
+			} else {
+				a.Status = storage.StatusApproved
+				a.ReviewNote = "Publishing was interrupted and the article was not found on the publisher"
+				slog.Warn("Interrupted publish did not go through, approved for retry", "id", a.ID, "title", a.Title)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,264 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/medium"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// fakePublisher records published titles and can fail or find posts.
+type fakePublisher struct {
+	published  []string
+	publishErr error
+	posts      map[string]string // Title to URL, for FindPost
+	findErr    error
+}
+
+func (p *fakePublisher) Publish(_ context.Context, a *article.Article) (string, error) {
+	if p.publishErr != nil {
+		return "", p.publishErr
+	}
+	p.published = append(p.published, a.Title)
+	return "https://medium.com/@me/" + a.Title, nil
+}
+
+func (p *fakePublisher) FindPost(_ context.Context, title string) (string, bool, error) {
+	if p.findErr != nil {
+		return "", false, p.findErr
+	}
+	url, ok := p.posts[title]
+	return url, ok, nil
+}
+
+// failingStore fails Update calls after the first n.
+type failingStore struct {
+	storage.Store
+	n int
+}
+
+func (s *failingStore) Update(change func(*storage.ArticleHistory) error) error {
+	if s.n == 0 {
+		return errors.New("disk full")
+	}
+	s.n--
+	return s.Store.Update(change)
+}
+
+func newTestStores(t *testing.T) (storage.Store, storage.ContentArchive) {
+	t.Helper()
+	dir := t.TempDir()
+	return storage.NewJSONStore(filepath.Join(dir, "articles.json")), storage.NewArchive(filepath.Join(dir, "archive"))
+}
+
+func get(t *testing.T, store storage.Store, id string) storage.ArticleRecord {
+	t.Helper()
+	a, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", id, err)
+	}
+	return a
+}
+
+func TestPublish(t *testing.T) {
+	store, archive := newTestStores(t)
+	pub := &fakePublisher{}
+	ctx := context.Background()
+
+	record, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "First", Topic: "Go"}, "Body")
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	stored := get(t, store, "a1")
+	if !stored.Published() || stored.URL != "https://medium.com/@me/First" || stored.ContentHash == "" || stored.ContentPath == "" {
+		t.Errorf("stored record = %+v", stored)
+	}
+	if record.URL != stored.URL {
+		t.Errorf("Publish() URL = %q, want %q", record.URL, stored.URL)
+	}
+
+	// The same article again, or the same content under another ID, is refused
+	for _, r := range []storage.ArticleRecord{{ID: "a1", Title: "First"}, {ID: "a2", Title: "Copy"}} {
+		_, err := Publish(ctx, store, archive, pub, r, "Body")
+		if !errors.Is(err, ErrAlreadyPublished) {
+			t.Errorf("Publish(%s) error = %v, want ErrAlreadyPublished", r.ID, err)
+		}
+	}
+	// A refused publish leaves the published article's content intact
+	if _, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "First"}, "Edited body"); !errors.Is(err, ErrAlreadyPublished) {
+		t.Errorf("Publish(a1) with new content error = %v, want ErrAlreadyPublished", err)
+	}
+	if content, err := archive.Get(get(t, store, "a1")); err != nil || content != "Body" {
+		t.Errorf("archive.Get(a1) = %q, %v; want the published content", content, err)
+	}
+	if len(pub.published) != 1 {
+		t.Errorf("published %v, want one article", pub.published)
+	}
+
+	if _, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{Title: "No ID"}, "Other"); err == nil {
+		t.Error("Publish() without an ID should fail")
+	}
+}
+
+func TestPublish_OnLive(t *testing.T) {
+	store, archive := newTestStores(t)
+	var live []storage.ArticleRecord
+	onLive := OnLive(func(record storage.ArticleRecord) {
+		// Called before the record is saved
+		if stored := get(t, store, record.ID); stored.Status != storage.StatusPublishing {
+			t.Errorf("stored status = %q when live, want publishing", stored.Status)
+		}
+		live = append(live, record)
+	})
+	if _, err := Publish(context.Background(), store, archive, &fakePublisher{}, storage.ArticleRecord{ID: "a1", Title: "First"}, "Body", onLive); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(live) != 1 || live[0].URL != "https://medium.com/@me/First" || !live[0].Published() {
+		t.Errorf("OnLive records = %+v, want the published record", live)
+	}
+
+	_, err := Publish(context.Background(), store, archive, &fakePublisher{publishErr: errors.New("status 503")}, storage.ArticleRecord{ID: "a2", Title: "Second"}, "Other", onLive)
+	if err == nil || len(live) != 1 {
+		t.Errorf("Publish() error = %v, OnLive calls = %d; want an error and no call", err, len(live))
+	}
+}
+
+func TestPublish_Failure(t *testing.T) {
+	ctx := context.Background()
+	generated := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	draft := storage.ArticleRecord{ID: "d1", Title: "Draft", PublishedAt: generated, Status: storage.StatusApproved}
+
+	t.Run("new article is removed", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		pub := &fakePublisher{publishErr: errors.New("status 503")}
+		if _, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Body"); err == nil {
+			t.Fatal("Publish() error = nil")
+		}
+		if _, err := store.Get("a1"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("write-ahead record left behind: %v", err)
+		}
+	})
+
+	t.Run("draft is restored", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		if err := store.Append(draft); err != nil {
+			t.Fatal(err)
+		}
+		pub := &fakePublisher{publishErr: errors.New("status 503")}
+		if _, err := Publish(ctx, store, archive, pub, draft, "Body"); err == nil {
+			t.Fatal("Publish() error = nil")
+		}
+		if got := get(t, store, "d1"); got.Status != storage.StatusApproved || !got.PublishedAt.Equal(generated) {
+			t.Errorf("draft after failure = %+v, want it approved as before", got)
+		}
+	})
+
+	t.Run("refused publish is not looked up", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		pub := &fakePublisher{
+			publishErr: fmt.Errorf("status 400: %w", medium.ErrNotPublished),
+			findErr:    errors.New("FindPost called"),
+		}
+		_, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Body")
+		if err == nil || errors.Is(err, pub.findErr) {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if _, err := store.Get("a1"); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("write-ahead record left behind: %v", err)
+		}
+	})
+
+	t.Run("post found despite the error", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		pub := &fakePublisher{publishErr: errors.New("timeout"), posts: map[string]string{"New": "https://medium.com/@me/new"}}
+		record, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Body")
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if got := get(t, store, "a1"); !got.Published() || got.URL != "https://medium.com/@me/new" || record.URL != got.URL {
+			t.Errorf("stored record = %+v", got)
+		}
+	})
+
+	t.Run("unknown outcome stays in flight", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		pub := &fakePublisher{publishErr: errors.New("timeout"), findErr: errors.New("offline")}
+		if _, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Body"); err == nil {
+			t.Fatal("Publish() error = nil")
+		}
+		if got := get(t, store, "a1"); got.Status != storage.StatusPublishing {
+			t.Errorf("status = %q, want publishing", got.Status)
+		}
+		pub.publishErr, pub.findErr = nil, nil
+		_, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a2", Title: "Retry"}, "Body")
+		if !errors.Is(err, ErrInFlight) {
+			t.Errorf("Publish() of in-flight content error = %v, want ErrInFlight", err)
+		}
+		if _, err := Publish(ctx, store, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Other"); !errors.Is(err, ErrInFlight) {
+			t.Errorf("Publish() of the in-flight article error = %v, want ErrInFlight", err)
+		}
+		if content, err := archive.Get(get(t, store, "a1")); err != nil || content != "Body" {
+			t.Errorf("archive.Get(a1) = %q, %v; want the in-flight content", content, err)
+		}
+	})
+
+	t.Run("not recorded", func(t *testing.T) {
+		store, archive := newTestStores(t)
+		pub := &fakePublisher{}
+		_, err := Publish(ctx, &failingStore{Store: store, n: 1}, archive, pub, storage.ArticleRecord{ID: "a1", Title: "New"}, "Body")
+		if !errors.Is(err, ErrNotRecorded) {
+			t.Fatalf("Publish() error = %v, want ErrNotRecorded", err)
+		}
+		if got := get(t, store, "a1"); got.Status != storage.StatusPublishing {
+			t.Errorf("status = %q, want publishing until reconciled", got.Status)
+		}
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	store, _ := newTestStores(t)
+	old := time.Now().Add(-time.Hour)
+	for _, a := range []storage.ArticleRecord{
+		{ID: "live", Title: "Live", Status: storage.StatusPublishing, PublishedAt: old},
+		{ID: "lost", Title: "Lost", Status: storage.StatusPublishing, PublishedAt: old},
+		{ID: "recent", Title: "Recent", Status: storage.StatusPublishing, PublishedAt: time.Now()},
+		{ID: "done", Title: "Done", URL: "https://medium.com/@me/done", PublishedAt: old},
+	} {
+		if err := store.Append(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Nothing changes while the publisher cannot be reached
+	pub := &fakePublisher{findErr: errors.New("offline")}
+	if err := Reconcile(context.Background(), store, pub); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := get(t, store, "live"); got.Status != storage.StatusPublishing {
+		t.Errorf("status after failed lookup = %q, want publishing", got.Status)
+	}
+
+	pub = &fakePublisher{posts: map[string]string{"Live": "https://medium.com/@me/live"}}
+	if err := Reconcile(context.Background(), store, pub); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	tests := []struct {
+		id, status, url string
+	}{
+		{"live", storage.StatusPublished, "https://medium.com/@me/live"},
+		{"lost", storage.StatusApproved, ""},
+		{"recent", storage.StatusPublishing, ""},
+		{"done", storage.StatusPublished, "https://medium.com/@me/done"},
+	}
+	for _, tt := range tests {
+		if got := get(t, store, tt.id); got.ReviewStatus() != tt.status || got.URL != tt.url {
+			t.Errorf("%s: status %q, URL %q; want %q, %q", tt.id, got.ReviewStatus(), got.URL, tt.status, tt.url)
+		}
+	}
+}
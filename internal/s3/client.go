@@ -0,0 +1,188 @@
+// Package s3 is a minimal client for S3-compatible object stores, such as
+// AWS S3 and MinIO, signing requests with AWS Signature Version 4.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the requested object does not exist.
+	ErrNotFound = errors.New("object not found")
+	// ErrPreconditionFailed is returned when a conditional write is refused
+	// because the object changed since it was read.
+	ErrPreconditionFailed = errors.New("object changed since it was read")
+)
+
+// Config locates a bucket and holds the credentials to access it.
+type Config struct {
+	Endpoint        string // e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	PathStyle       bool // Address the bucket as a path rather than a subdomain, as MinIO expects
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Temporary credentials only
+}
+
+// Client reads and writes objects in one bucket.
+type Client struct {
+	endpoint  *url.URL
+	bucket    string
+	pathStyle bool
+	creds     credentials
+	client    *http.Client
+	now       func() time.Time
+}
+
+// New creates a client for the bucket described by cfg.
+func New(cfg Config) (*Client, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", cfg.Endpoint)
+	}
+	return &Client{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		pathStyle: cfg.PathStyle,
+		creds: credentials{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			region:          cfg.Region,
+		},
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}, nil
+}
+
+// Bucket returns the name of the client's bucket.
+func (c *Client) Bucket() string {
+	return c.bucket
+}
+
+// PutOptions control a write.
+type PutOptions struct {
+	ContentType string
+	IfMatch     string // Only replace the object if its ETag is still this
+	IfNoneMatch bool   // Only create the object if it does not exist yet
+}
+
+// Get returns the content and ETag of an object.
+func (c *Client) Get(ctx context.Context, key string) (data []byte, etag string, err error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// Put writes an object and returns its new ETag. A refused conditional
+// write fails with ErrPreconditionFailed.
+func (c *Client) Put(ctx context.Context, key string, data []byte, opts PutOptions) (etag string, err error) {
+	header := make(http.Header)
+	if opts.ContentType != "" {
+		header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.IfMatch != "" {
+		header.Set("If-Match", opts.IfMatch)
+	}
+	if opts.IfNoneMatch {
+		header.Set("If-None-Match", "*")
+	}
+	resp, err := c.do(ctx, http.MethodPut, key, data, header)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// do sends a signed request and turns error responses into errors.
+func (c *Client) do(ctx context.Context, method, key string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		payloadHash = hashHex(body)
+	}
+	c.creds.sign(req, payloadHash, c.now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, key, err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		// S3 answers 409 ConditionalRequestConflict to a write racing another
+		return nil, fmt.Errorf("%w: %s", ErrPreconditionFailed, key)
+	}
+	return nil, fmt.Errorf("%s %s: %s", method, key, errorMessage(resp))
+}
+
+// objectURL returns the URL of key, addressing the bucket by subdomain or
+// by path.
+func (c *Client) objectURL(key string) string {
+	u := *c.endpoint
+	path := strings.TrimSuffix(u.Path, "/")
+	if c.pathStyle {
+		path += "/" + c.bucket
+	} else {
+		u.Host = c.bucket + "." + u.Host
+	}
+	u.Path = path + "/" + key
+	u.RawPath = ""
+	return u.String()
+}
+
+// errorMessage describes an S3 error response.
+func errorMessage(resp *http.Response) string {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(data, &body) == nil && body.Code != "" {
+		return fmt.Sprintf("%s (%s: %s)", resp.Status, body.Code, body.Message)
+	}
+	return resp.Status
+}
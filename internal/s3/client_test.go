@@ -0,0 +1,115 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/s3/s3test"
+)
+
+func newTestClient(t *testing.T) (*Client, *s3test.Server) {
+	t.Helper()
+	server := s3test.NewServer("blog")
+	t.Cleanup(server.Close)
+	client, err := New(Config{
+		Endpoint:        server.URL,
+		Bucket:          "blog",
+		PathStyle:       true,
+		AccessKeyID:     s3test.AccessKeyID,
+		SecretAccessKey: s3test.SecretAccessKey,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client, server
+}
+
+func TestClient_GetPut(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	if _, _, err := client.Get(ctx, "missing.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of a missing object error = %v, want ErrNotFound", err)
+	}
+
+	etag, err := client.Put(ctx, "dir/a b.json", []byte("one"), PutOptions{ContentType: "application/json"})
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if etag != s3test.ETag([]byte("one")) {
+		t.Errorf("Put() ETag = %s", etag)
+	}
+	if data, ok := server.Object("dir/a b.json"); !ok || string(data) != "one" {
+		t.Errorf("stored object = %q, %v", data, ok)
+	}
+
+	data, got, err := client.Get(ctx, "dir/a b.json")
+	if err != nil || string(data) != "one" || got != etag {
+		t.Errorf("Get() = %q, %s, %v", data, got, err)
+	}
+}
+
+func TestClient_ConditionalPut(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	etag, err := client.Put(ctx, "h.json", []byte("v1"), PutOptions{IfNoneMatch: true})
+	if err != nil {
+		t.Fatalf("create-only Put() error = %v", err)
+	}
+	if _, err := client.Put(ctx, "h.json", []byte("v1"), PutOptions{IfNoneMatch: true}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("create-only Put() of an existing object error = %v, want ErrPreconditionFailed", err)
+	}
+
+	// Another writer replaces the object after it was read
+	server.SetObject("h.json", []byte("other"))
+	if _, err := client.Put(ctx, "h.json", []byte("v2"), PutOptions{IfMatch: etag}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("Put() with a stale ETag error = %v, want ErrPreconditionFailed", err)
+	}
+	if data, _ := server.Object("h.json"); string(data) != "other" {
+		t.Errorf("refused Put() changed the object to %q", data)
+	}
+
+	if _, err := client.Put(ctx, "h.json", []byte("v2"), PutOptions{IfMatch: s3test.ETag([]byte("other"))}); err != nil {
+		t.Errorf("Put() with the current ETag error = %v", err)
+	}
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	client, _ := newTestClient(t)
+	client.creds.accessKeyID = "wrong"
+
+	_, _, err := client.Get(context.Background(), "x")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Get() with bad credentials error = %v, want the S3 error code", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantURL string
+		wantErr bool
+	}{
+		{"aws default", Config{Bucket: "b", Region: "eu-west-1"}, "https://b.s3.eu-west-1.amazonaws.com/k/x.md", false},
+		{"path style", Config{Bucket: "b", Endpoint: "http://minio:9000", PathStyle: true}, "http://minio:9000/b/k/x.md", false},
+		{"no bucket", Config{}, "", true},
+		{"bad endpoint", Config{Bucket: "b", Endpoint: "minio"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if got := client.objectURL("k/x.md"); got != tt.wantURL {
+					t.Errorf("objectURL() = %s, want %s", got, tt.wantURL)
+				}
+			}
+		})
+	}
+}
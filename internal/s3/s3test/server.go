@@ -0,0 +1,129 @@
+// Package s3test provides an in-memory S3-compatible server for tests.
+package s3test
+
+import (
+	"crypto/md5" // #nosec G501 -- S3 ETags are MD5 digests, not used for security
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Credentials accepted by the server.
+const (
+	AccessKeyID     = "test-access-key"
+	SecretAccessKey = "test-secret-key"
+)
+
+// Server is a path-style S3 endpoint supporting GET and PUT of objects with
+// ETags and If-Match / If-None-Match conditional writes.
+type Server struct {
+	*httptest.Server
+	Bucket string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    int
+}
+
+// NewServer starts a server holding an empty bucket. Close it when done.
+func NewServer(bucket string) *Server {
+	s := &Server{Bucket: bucket, objects: make(map[string][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Object returns the content of an object.
+func (s *Server) Object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	return data, ok
+}
+
+// SetObject writes an object directly, as another client would.
+func (s *Server) SetObject(key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+}
+
+// Keys returns the keys of every object.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Puts returns the number of successful writes.
+func (s *Server) Puts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.puts
+}
+
+// ETag returns the ETag of data.
+func ETag(data []byte) string {
+	sum := md5.Sum(data) // #nosec G401 -- S3 ETags are MD5 digests
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential="+AccessKeyID+"/") || r.Header.Get("X-Amz-Date") == "" {
+		writeError(w, http.StatusForbidden, "AccessDenied", "missing or unknown credentials")
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "unknown bucket "+bucket)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.objects[key]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "the specified key does not exist")
+			return
+		}
+		w.Header().Set("ETag", ETag(current))
+		_, _ = w.Write(current)
+
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); match != "" && (!exists || match != ETag(current)) {
+			writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "If-Match does not match")
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "object exists")
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		s.objects[key] = data
+		s.puts++
+		w.Header().Set("ETag", ETag(data))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
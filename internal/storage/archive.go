@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContentArchive keeps the published content of articles.
+type ContentArchive interface {
+	// Put stores the content of an article and records its location, hash
+	// and word count. The record must have an ID.
+	Put(record *ArticleRecord, content string) error
+	// Get returns the stored content of an article. It fails if the content
+	// was not archived or no longer matches the recorded hash.
+	Get(record ArticleRecord) (string, error)
+}
+
+// Archive keeps the published content of each article in its own file,
+// named after the article ID, so titles never collide.
+type Archive struct {
+	dir string
+}
+
+// NewArchive creates an archive in dir.
+func NewArchive(dir string) *Archive {
+	return &Archive{dir: dir}
+}
+
+// Put writes the content of an article and records its path, hash and word
+// count. The record must have an ID.
+func (a *Archive) Put(record *ArticleRecord, content string) error {
+	if record.ID == "" {
+		return fmt.Errorf("article %q has no ID", record.Title)
+	}
+	// #nosec G301 -- 0755 is appropriate for output directory
+	if err := os.MkdirAll(a.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	path := filepath.Join(a.dir, record.ID+".md")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return err
+	}
+
+	describeContent(record, filepath.ToSlash(path), content)
+	return nil
+}
+
+// describeContent records where content was archived, its hash and its
+// word count.
+func describeContent(record *ArticleRecord, path, content string) {
+	record.ContentPath = path
+	record.ContentHash = ContentHash([]byte(content))
+	record.WordCount = len(strings.Fields(content))
+}
+
+// Get reads the archived content of an article. It fails if the content
+// was not archived or no longer matches the recorded hash.
+func (a *Archive) Get(record ArticleRecord) (string, error) {
+	if record.ContentPath == "" {
+		return "", fmt.Errorf("article %q has no archived content", record.Title)
+	}
+	// #nosec G304 -- path was recorded by Put
+	data, err := os.ReadFile(filepath.FromSlash(record.ContentPath))
+	if err != nil {
+		return "", err
+	}
+	return verifyContent(record, data)
+}
+
+// verifyContent checks archived content against the recorded hash.
+func verifyContent(record ArticleRecord, data []byte) (string, error) {
+	if record.ContentHash != "" && ContentHash(data) != record.ContentHash {
+		return "", fmt.Errorf("archived content of %q does not match its hash", record.Title)
+	}
+	return string(data), nil
+}
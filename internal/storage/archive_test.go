@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	archive := NewArchive(filepath.Join(t.TempDir(), "archive"))
+	record := ArticleRecord{ID: "abc123", Title: "Go Channels"}
+
+	content := "# Go Channels\n\nChannels connect goroutines."
+	if err := archive.Put(&record, content); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if record.WordCount != 6 || record.ContentHash != ContentHash([]byte(content)) || filepath.Base(record.ContentPath) != "abc123.md" {
+		t.Errorf("record = %+v", record)
+	}
+
+	got, err := archive.Get(record)
+	if err != nil || got != content {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.FromSlash(record.ContentPath), []byte("changed"), 0600); err != nil {
+		t.Fatalf("Failed to modify archive: %v", err)
+	}
+	if _, err := archive.Get(record); err == nil {
+		t.Error("Get() should detect content that no longer matches its hash")
+	}
+
+	if err := archive.Put(&ArticleRecord{Title: "No ID"}, content); err == nil {
+		t.Error("Put() should require an ID")
+	}
+	if _, err := archive.Get(ArticleRecord{Title: "Legacy"}); err == nil {
+		t.Error("Get() should fail for records without archived content")
+	}
+}
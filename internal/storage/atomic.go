@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// backupCopies is the number of previous versions of the history file
+	// kept as <path>.bak.1 (newest) to <path>.bak.N.
+	backupCopies = 3
+	// lockTimeout bounds how long a writer waits for another process, such
+	// as a manual run overlapping the scheduled job, to release the lock.
+	lockTimeout = 30 * time.Second
+	// lockRetry is the interval between attempts to take the lock.
+	lockRetry = 50 * time.Millisecond
+)
+
+// writeFileAtomic replaces path with data so that readers and a crash
+// leave either the old or the new content, never a partial file: data is
+// written and synced to a temporary file in the same directory, which is
+// then renamed over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		// A file bind-mounted into a container cannot be replaced
+		if overwriteErr := overwriteFile(path, data); overwriteErr != nil {
+			return errors.Join(err, overwriteErr)
+		}
+		slog.Warn("Could not replace file atomically, rewrote it in place", "path", path, "error", err)
+		_ = os.Remove(tmp.Name())
+		return nil
+	}
+	// Make the rename itself durable
+	return syncDir(filepath.Dir(path))
+}
+
+// overwriteFile rewrites an existing file in place and syncs it.
+func overwriteFile(path string, data []byte) error {
+	// #nosec G304 -- path is the configured history file
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// backupPath returns the name of the nth most recent backup of path.
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", path, n)
+}
+
+// rotateBackups shifts the backups of path by one, dropping the oldest, and
+// saves the current content of path as the newest. A missing path is not
+// an error: there is nothing to back up yet.
+func rotateBackups(path string, copies int) error {
+	current, err := os.ReadFile(path) // #nosec G304 -- path is the configured history file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for n := copies; n > 1; n-- {
+		err := os.Rename(backupPath(path, n-1), backupPath(path, n))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return writeFileAtomic(backupPath(path, 1), current, 0600)
+}
+
+// withLock runs fn while holding the advisory lock of path, waiting up to
+// lockTimeout for another holder to release it.
+func withLock(path string, fn func() error) error {
+	unlock, err := lockFile(path+".lock", lockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	defer unlock()
+	return fn()
+}
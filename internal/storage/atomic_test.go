@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "articles.json")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new"), 0600); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "new" {
+		t.Errorf("content = %q, want new", got)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries, want only the file (no leftover temp files)", len(entries))
+	}
+
+	// A failed write leaves the original untouched
+	if err := writeFileAtomic(filepath.Join(dir, "missing", "articles.json"), []byte("x"), 0600); err == nil {
+		t.Error("writeFileAtomic() into a missing directory should fail")
+	}
+	if got, _ := os.ReadFile(path); string(got) != "new" {
+		t.Errorf("content after failed write = %q, want new", got)
+	}
+}
+
+func TestRotateBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.json")
+	if err := rotateBackups(path, 2); err != nil {
+		t.Fatalf("rotateBackups() without a file error = %v", err)
+	}
+
+	for _, version := range []string{"v1", "v2", "v3", "v4"} {
+		if err := os.WriteFile(path, []byte(version), 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if err := rotateBackups(path, 2); err != nil {
+			t.Fatalf("rotateBackups() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{backupPath(path, 1), "v4"},
+		{backupPath(path, 2), "v3"},
+	}
+	for _, tt := range tests {
+		if got, err := os.ReadFile(tt.path); err != nil || string(got) != tt.want {
+			t.Errorf("%s = %q, %v, want %q", filepath.Base(tt.path), got, err, tt.want)
+		}
+	}
+	if _, err := os.Stat(backupPath(path, 3)); !os.IsNotExist(err) {
+		t.Errorf("backup beyond the limit exists (err %v)", err)
+	}
+}
+
+func TestJSONStore_SaveKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.json")
+	store := NewJSONStore(path)
+	for i := range backupCopies + 2 {
+		if err := store.Append(contractRecord(fmt.Sprint(i), "Go", i)); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// The newest backup is the history before the last append
+	previous, err := NewJSONStore(backupPath(path, 1)).Load()
+	if err != nil {
+		t.Fatalf("Load() backup error = %v", err)
+	}
+	if len(previous.Articles) != backupCopies+1 {
+		t.Errorf("newest backup has %d articles, want %d", len(previous.Articles), backupCopies+1)
+	}
+	if _, err := os.Stat(backupPath(path, backupCopies+1)); !os.IsNotExist(err) {
+		t.Errorf("more than %d backups kept (err %v)", backupCopies, err)
+	}
+}
+
+func TestJSONStore_ConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.json")
+
+	// Separate stores stand in for overlapping runs
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- NewJSONStore(path).Append(contractRecord(fmt.Sprint("w", i), "Go", i))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	history, err := NewJSONStore(path).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(history.Articles) != writers {
+		t.Errorf("history has %d articles, want %d: concurrent updates were lost", len(history.Articles), writers)
+	}
+}
+
+func TestLockFile_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.json.lock")
+	unlock, err := lockFile(path, time.Second)
+	if err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+
+	if _, err := lockFile(path, 100*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("lockFile() while held error = %v, want a timeout", err)
+	}
+
+	unlock()
+	again, err := lockFile(path, time.Second)
+	if err != nil {
+		t.Fatalf("lockFile() after unlock error = %v", err)
+	}
+	again()
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// contractDay is the base publication date of contract test articles.
+var contractDay = time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+// contractRecord returns a fully populated article published n days after
+// contractDay.
+func contractRecord(id, topic string, n int, tags ...string) ArticleRecord {
+	return ArticleRecord{
+		ID:          id,
+		Title:       "Article " + id,
+		Topic:       topic,
+		Category:    "Go",
+		PublishedAt: contractDay.AddDate(0, 0, n),
+		URL:         "https://medium.com/@me/" + id,
+		Tags:        tags,
+		Keywords:    []string{"channels"},
+		References:  []Reference{{Document: "spec.pdf", CitedText: "quote", Location: "p. 2"}},
+		Fingerprint: []uint32{1, 2, 3},
+		ContentHash: "sha256:abc",
+		WordCount:   1200,
+		Summary:     "A summary.",
+		Model:       "claude-sonnet-4-20250514",
+		PromptHash:  "sha256:def",
+		Usage:       &Usage{InputTokens: 900, OutputTokens: 2100},
+		Format:      "tutorial",
+		ContentPath: "archive/" + id + ".md",
+	}
+}
+
+// testStoreContract checks the behaviour every Store backend must provide.
+// newStore returns an empty store.
+func testStoreContract(t *testing.T, newStore func(t *testing.T) Store) {
+	t.Run("load empty", func(t *testing.T) {
+		history, err := newStore(t).Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if history == nil || len(history.Articles) != 0 {
+			t.Errorf("Load() = %+v, want empty history", history)
+		}
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		store := newStore(t)
+		want := []ArticleRecord{contractRecord("a1", "Go", 0, "go", "concurrency"), contractRecord("a2", "AI", 1)}
+		want[1].Status, want[1].ReviewNote = StatusRejected, "Too long"
+		if err := store.Save(&ArticleHistory{Articles: want}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		history, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if history.SchemaVersion != SchemaVersion {
+			t.Errorf("SchemaVersion = %d, want %d", history.SchemaVersion, SchemaVersion)
+		}
+		if !reflect.DeepEqual(history.Articles, want) {
+			t.Errorf("Load() articles =\n%+v\nwant\n%+v", history.Articles, want)
+		}
+
+		// Save replaces, it does not merge
+		if err := store.Save(&ArticleHistory{Articles: want[1:]}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if history, _ := store.Load(); len(history.Articles) != 1 {
+			t.Errorf("Load() after second Save() = %d articles, want 1", len(history.Articles))
+		}
+	})
+
+	t.Run("append and get", func(t *testing.T) {
+		store := newStore(t)
+		first := contractRecord("a1", "Go", 0)
+		if err := store.Append(first); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+		unnamed := contractRecord("", "Go", 1)
+		if err := store.Append(unnamed); err != nil {
+			t.Fatalf("Append() without ID error = %v", err)
+		}
+		if err := store.Append(first); !errors.Is(err, ErrExists) {
+			t.Errorf("Append() of an existing ID error = %v, want ErrExists", err)
+		}
+
+		got, err := store.Get("a1")
+		if err != nil || !reflect.DeepEqual(got, first) {
+			t.Errorf("Get(a1) = %+v, %v", got, err)
+		}
+		id := RecordID(unnamed.Title, unnamed.PublishedAt)
+		if got, err := store.Get(id); err != nil || got.Title != unnamed.Title {
+			t.Errorf("Get(%s) = %+v, %v, want the appended article with a derived ID", id, got, err)
+		}
+		if _, err := store.Get("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+		}
+
+		history, err := store.Load()
+		if err != nil || len(history.Articles) != 2 || history.Articles[0].ID != "a1" {
+			t.Errorf("Load() after Append() = %+v, %v, want both articles in order", history, err)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		store := newStore(t)
+		draft := contractRecord("draft-1", "Go", 5, "go")
+		draft.Status, draft.URL = StatusPending, ""
+		for _, a := range []ArticleRecord{
+			contractRecord("go-1", "Go", 0, "go"),
+			contractRecord("ai-1", "AI", 1, "ml", "Go"),
+			contractRecord("go-2", "Go", 2, "testing"),
+			contractRecord("go-3", "Go", 10, "go"),
+			draft,
+		} {
+			if err := store.Append(a); err != nil {
+				t.Fatalf("Append() error = %v", err)
+			}
+		}
+
+		tests := []struct {
+			name string
+			q    Query
+			want []string
+		}{
+			{"all, newest first", Query{}, []string{"go-3", "draft-1", "go-2", "ai-1", "go-1"}},
+			{"topic", Query{Topic: "Go"}, []string{"go-3", "draft-1", "go-2", "go-1"}},
+			{"tag ignores case", Query{Tag: "GO"}, []string{"go-3", "draft-1", "ai-1", "go-1"}},
+			{"date range", Query{Since: contractDay.AddDate(0, 0, 1), Until: contractDay.AddDate(0, 0, 10)}, []string{"draft-1", "go-2", "ai-1"}},
+			{"published", Query{Status: StatusPublished}, []string{"go-3", "go-2", "ai-1", "go-1"}},
+			{"pending", Query{Topic: "Go", Status: StatusPending}, []string{"draft-1"}},
+			{"combined with limit", Query{Topic: "Go", Tag: "go", Limit: 1}, []string{"go-3"}},
+			{"no match", Query{Category: "Rust"}, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := store.Query(tt.q)
+				if err != nil {
+					t.Fatalf("Query() error = %v", err)
+				}
+				var ids []string
+				for _, a := range got {
+					ids = append(ids, a.ID)
+				}
+				if !reflect.DeepEqual(ids, tt.want) {
+					t.Errorf("Query() = %v, want %v", ids, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Append(contractRecord("a1", "Go", 0)); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+
+		err := store.Update(func(h *ArticleHistory) error {
+			h.Articles[0].URL = "https://example.com/moved"
+			h.Articles = append(h.Articles, contractRecord("a2", "AI", 1))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if got, _ := store.Get("a1"); got.URL != "https://example.com/moved" {
+			t.Errorf("URL after Update() = %q", got.URL)
+		}
+		if _, err := store.Get("a2"); err != nil {
+			t.Errorf("Get(a2) after Update() error = %v", err)
+		}
+
+		failure := errors.New("abort")
+		err = store.Update(func(h *ArticleHistory) error {
+			h.Articles = nil
+			return failure
+		})
+		if !errors.Is(err, failure) {
+			t.Errorf("Update() error = %v, want the change's error", err)
+		}
+		if history, _ := store.Load(); len(history.Articles) != 2 {
+			t.Errorf("failed Update() changed the store: %d articles", len(history.Articles))
+		}
+	})
+}
+
+func TestJSONStore_Contract(t *testing.T) {
+	testStoreContract(t, func(t *testing.T) Store {
+		return NewJSONStore(filepath.Join(t.TempDir(), "articles.json"))
+	})
+}
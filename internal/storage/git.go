@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+// GitStore keeps the article history in a JSON file inside a git working
+// tree and commits every change, along with the configured content paths,
+// with a structured message. Reads come from the working tree.
+type GitStore struct {
+	json *JSONStore
+	path string // History file, absolute
+	dir  string // Directory git runs in
+	cfg  config.GitConfig
+}
+
+// NewGitStore creates a store for the history file at path, which must be
+// inside a git working tree.
+func NewGitStore(path string, cfg config.GitConfig) (*GitStore, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &GitStore{json: NewJSONStore(path), path: abs, dir: filepath.Dir(abs), cfg: cfg}
+	if _, err := s.git("rev-parse", "--show-toplevel"); err != nil {
+		return nil, fmt.Errorf("%s is not in a git repository: %w", path, err)
+	}
+	return s, nil
+}
+
+// Load reads the history from the working tree.
+func (s *GitStore) Load() (*ArticleHistory, error) {
+	return s.json.Load()
+}
+
+// Save replaces the history and commits it.
+func (s *GitStore) Save(history *ArticleHistory) error {
+	return withLock(s.json.filepath, func() error {
+		if err := s.json.save(history); err != nil {
+			return err
+		}
+		return s.commit(commitMessage(nil, history))
+	})
+}
+
+// Append adds an article and commits it.
+func (s *GitStore) Append(record ArticleRecord) error {
+	return s.Update(func(history *ArticleHistory) error {
+		return appendRecord(history, record)
+	})
+}
+
+// Get returns the article with the given ID.
+func (s *GitStore) Get(id string) (ArticleRecord, error) {
+	return s.json.Get(id)
+}
+
+// Query returns the matching articles, newest first.
+func (s *GitStore) Query(q Query) ([]ArticleRecord, error) {
+	return s.json.Query(q)
+}
+
+// Update applies change to the history and commits the result, describing
+// the articles it added.
+func (s *GitStore) Update(change func(*ArticleHistory) error) error {
+	return withLock(s.json.filepath, func() error {
+		var before map[string]string
+		history, err := s.json.update(func(h *ArticleHistory) error {
+			before = make(map[string]string, len(h.Articles))
+			for _, a := range h.Articles {
+				before[a.ID] = a.ReviewStatus()
+			}
+			return change(h)
+		})
+		if err != nil {
+			return err
+		}
+		return s.commit(commitMessage(before, history))
+	})
+}
+
+// Close does nothing; every change is committed as it is made.
+func (s *GitStore) Close() error {
+	return nil
+}
+
+// commit stages the history file and content paths, commits them if they
+// changed and pushes to the configured remote.
+func (s *GitStore) commit(message string) error {
+	paths := []string{s.path}
+	for _, p := range s.cfg.Paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(abs); err == nil {
+			paths = append(paths, abs)
+		}
+	}
+
+	if _, err := s.git(append([]string{"add", "--all", "--"}, paths...)...); err != nil {
+		return err
+	}
+	if _, err := s.git(append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+		return nil // Nothing changed
+	}
+
+	var args []string
+	if s.cfg.AuthorName != "" {
+		args = append(args, "-c", "user.name="+s.cfg.AuthorName)
+	}
+	if s.cfg.AuthorEmail != "" {
+		args = append(args, "-c", "user.email="+s.cfg.AuthorEmail)
+	}
+	args = append(args, "commit", "--quiet", "--message", message, "--")
+	if _, err := s.git(append(args, paths...)...); err != nil {
+		return err
+	}
+
+	if s.cfg.Remote == "" {
+		return nil
+	}
+	ref := "HEAD"
+	if s.cfg.Branch != "" {
+		ref = "HEAD:refs/heads/" + s.cfg.Branch
+	}
+	if _, err := s.git("push", "--quiet", s.cfg.Remote, ref); err != nil {
+		return fmt.Errorf("committed, but %w", err)
+	}
+	return nil
+}
+
+// git runs a git command in the history file's directory.
+func (s *GitStore) git(args ...string) (string, error) {
+	// #nosec G204 -- arguments are passed directly to git, not through a shell
+	cmd := exec.Command("git", append([]string{"-C", s.dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// commitMessage describes a history change: the article it added or whose
+// review status changed, with its metadata as git trailers, or the articles
+// added or kept. before maps the IDs present before the change to their
+// review status; nil means the history was replaced.
+func commitMessage(before map[string]string, history *ArticleHistory) string {
+	var changed []ArticleRecord
+	if before != nil {
+		for _, a := range history.Articles {
+			if status, ok := before[a.ID]; !ok || status != a.ReviewStatus() {
+				changed = append(changed, a)
+			}
+		}
+	}
+
+	var b strings.Builder
+	switch len(changed) {
+	case 0:
+		fmt.Fprintf(&b, "Update article history [skip ci]\n\nArticles: %d\n", len(history.Articles))
+	case 1:
+		a := changed[0]
+		fmt.Fprintf(&b, "%s %q [skip ci]\n\n", commitVerbs[a.ReviewStatus()], a.Title)
+		trailer(&b, "Article-ID", a.ID)
+		if !a.Published() {
+			trailer(&b, "Status", a.Status)
+			trailer(&b, "Review-Note", a.ReviewNote)
+		}
+		trailer(&b, "Topic", a.Topic)
+		trailer(&b, "Category", a.Category)
+		trailer(&b, "Published-At", a.PublishedAt.UTC().Format(time.RFC3339))
+		trailer(&b, "URL", a.URL)
+		trailer(&b, "Content-Hash", a.ContentHash)
+		trailer(&b, "Content-Path", a.ContentPath)
+	default:
+		fmt.Fprintf(&b, "Add %d articles to history [skip ci]\n\n", len(changed))
+		for _, a := range changed {
+			trailer(&b, "Article-ID", a.ID)
+		}
+	}
+	return b.String()
+}
+
+// commitVerbs start the subject of a commit changing one article, by its
+// review status.
+var commitVerbs = map[string]string{
+	StatusPublished:  "Publish",
+	StatusPublishing: "Start publishing",
+	StatusPending:    "Draft",
+	StatusApproved:   "Approve",
+	StatusRejected:   "Reject",
+}
+
+// trailer writes a "Key: value" line, skipping empty values.
+func trailer(b *strings.Builder, key, value string) {
+	if value = strings.Join(strings.Fields(value), " "); value != "" {
+		fmt.Fprintf(b, "%s: %s\n", key, value)
+	}
+}
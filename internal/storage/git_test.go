@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+// testGitConfig commits as a fixed author, whatever the machine's git config.
+var testGitConfig = config.GitConfig{AuthorName: "Tester", AuthorEmail: "tester@example.com"}
+
+// initGitRepo creates a repository with one commit on main and returns a
+// function running git in it.
+func initGitRepo(t *testing.T) (dir string, run func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir = t.TempDir()
+	run = func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(),
+			"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=tester@example.com",
+			"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=tester@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "initial")
+	return dir, run
+}
+
+func TestGitStore_Contract(t *testing.T) {
+	testStoreContract(t, func(t *testing.T) Store {
+		dir, _ := initGitRepo(t)
+		store, err := NewGitStore(filepath.Join(dir, "articles.json"), testGitConfig)
+		if err != nil {
+			t.Fatalf("NewGitStore() error = %v", err)
+		}
+		return store
+	})
+}
+
+func TestGitStore_CommitsAndPushes(t *testing.T) {
+	dir, run := initGitRepo(t)
+	remote := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v\n%s", err, out)
+	}
+	run("remote", "add", "origin", remote)
+
+	cfg := testGitConfig
+	cfg.Paths = []string{filepath.Join(dir, "archive"), filepath.Join(dir, "generated")}
+	cfg.Remote, cfg.Branch = "origin", "main"
+	store, err := NewGitStore(filepath.Join(dir, "articles.json"), cfg)
+	if err != nil {
+		t.Fatalf("NewGitStore() error = %v", err)
+	}
+
+	// Content is archived before the article is recorded; generated/ does
+	// not exist and is skipped
+	archive := NewArchive(filepath.Join(dir, "archive"))
+	record := contractRecord("a1", "Go", 0)
+	if err := archive.Put(&record, "Body"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := store.Append(record); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	message := run("log", "-1", "--format=%B")
+	for _, want := range []string{`Publish "Article a1" [skip ci]`, "Article-ID: a1", "Topic: Go", "Published-At: 2025-05-01T09:00:00Z", "URL: https://medium.com/@me/a1"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("commit message missing %q:\n%s", want, message)
+		}
+	}
+	if files := run("show", "--name-only", "--format=", "HEAD"); files != "archive/a1.md\narticles.json" {
+		t.Errorf("committed files = %q, want the archived content and history", files)
+	}
+	if pushed := strings.TrimSpace(mustGit(t, "--git-dir", remote, "log", "-1", "--format=%s", "main")); pushed != `Publish "Article a1" [skip ci]` {
+		t.Errorf("remote main = %q, want the article commit", pushed)
+	}
+
+	// Reads come from the working tree
+	if got, err := store.Get("a1"); err != nil || got.Title != record.Title {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+
+	// An update that changes nothing makes no commit
+	commits := run("rev-list", "--count", "HEAD")
+	if err := store.Update(func(*ArticleHistory) error { return nil }); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := run("rev-list", "--count", "HEAD"); got != commits {
+		t.Errorf("no-op Update() made a commit: %s commits, want %s", got, commits)
+	}
+
+	history, _ := store.Load()
+	history.Articles[0].URL = "https://example.com/moved"
+	if err := store.Save(history); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if subject := run("log", "-1", "--format=%s"); subject != "Update article history [skip ci]" {
+		t.Errorf("Save() commit subject = %q", subject)
+	}
+}
+
+func TestGitStore_PushFailure(t *testing.T) {
+	dir, run := initGitRepo(t)
+	run("remote", "add", "origin", filepath.Join(t.TempDir(), "missing.git"))
+	cfg := testGitConfig
+	cfg.Remote = "origin"
+	store, err := NewGitStore(filepath.Join(dir, "articles.json"), cfg)
+	if err != nil {
+		t.Fatalf("NewGitStore() error = %v", err)
+	}
+
+	err = store.Append(contractRecord("a1", "Go", 0))
+	if err == nil || !strings.Contains(err.Error(), "committed, but git push failed") {
+		t.Errorf("Append() error = %v, want the push failure", err)
+	}
+	if subject := run("log", "-1", "--format=%s"); !strings.HasPrefix(subject, "Publish") {
+		t.Errorf("article was not committed locally: HEAD is %q", subject)
+	}
+}
+
+func TestNewGitStore_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if _, err := NewGitStore(filepath.Join(dir, "articles.json"), testGitConfig); err == nil {
+		t.Error("NewGitStore() outside a repository should fail")
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	history := &ArticleHistory{Articles: []ArticleRecord{contractRecord("a1", "Go", 0), contractRecord("a2", "AI", 1)}}
+
+	tests := []struct {
+		name   string
+		before map[string]string
+		want   string
+	}{
+		{"replaced", nil, "Update article history [skip ci]\n\nArticles: 2\n"},
+		{"added two", map[string]string{}, "Add 2 articles to history [skip ci]\n\nArticle-ID: a1\nArticle-ID: a2\n"},
+		{"added one", map[string]string{"a1": StatusPublished}, "Publish \"Article a2\" [skip ci]\n\nArticle-ID: a2\nTopic: AI\nCategory: Go\n" +
+			"Published-At: 2025-05-02T09:00:00Z\nURL: https://medium.com/@me/a2\nContent-Hash: sha256:abc\nContent-Path: archive/a2.md\n"},
+		{"draft published", map[string]string{"a1": StatusPublished, "a2": StatusApproved}, "Publish \"Article a2\" [skip ci]\n\nArticle-ID: a2\nTopic: AI\nCategory: Go\n" +
+			"Published-At: 2025-05-02T09:00:00Z\nURL: https://medium.com/@me/a2\nContent-Hash: sha256:abc\nContent-Path: archive/a2.md\n"},
+		{"publish completed", map[string]string{"a1": StatusPublished, "a2": StatusPublishing}, "Publish \"Article a2\" [skip ci]\n\nArticle-ID: a2\nTopic: AI\nCategory: Go\n" +
+			"Published-At: 2025-05-02T09:00:00Z\nURL: https://medium.com/@me/a2\nContent-Hash: sha256:abc\nContent-Path: archive/a2.md\n"},
+		{"unchanged", map[string]string{"a1": StatusPublished, "a2": StatusPublished}, "Update article history [skip ci]\n\nArticles: 2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitMessage(tt.before, history); got != tt.want {
+				t.Errorf("commitMessage() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	draft := contractRecord("d1", "Go", 3)
+	draft.Status, draft.ReviewNote, draft.URL = StatusRejected, "Off topic", ""
+	got := commitMessage(map[string]string{"d1": StatusPending}, &ArticleHistory{Articles: []ArticleRecord{draft}})
+	if want := "Reject \"Article d1\" [skip ci]\n\nArticle-ID: d1\nStatus: rejected\nReview-Note: Off topic\nTopic: Go\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("commitMessage() for a rejected draft =\n%s\nwant prefix\n%s", got, want)
+	}
+
+	draft.Status, draft.ReviewNote = StatusPublishing, ""
+	got = commitMessage(map[string]string{"d1": StatusApproved}, &ArticleHistory{Articles: []ArticleRecord{draft}})
+	if want := "Start publishing \"Article d1\" [skip ci]\n\nArticle-ID: d1\nStatus: publishing\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("commitMessage() for a publishing record =\n%s\nwant prefix\n%s", got, want)
+	}
+}
+
+// mustGit runs git outside any repository directory.
+func mustGit(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
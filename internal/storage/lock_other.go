@@ -0,0 +1,46 @@
+//go:build !unix
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// staleLockAge is the age after which a lock file left behind by a crashed
+// process is removed. Without flock, nothing releases it automatically.
+const staleLockAge = 10 * time.Minute
+
+// lockFile takes the lock by creating path exclusively and releases it by
+// removing the file.
+func lockFile(path string, timeout time.Duration) (unlock func(), err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		// #nosec G304 -- lock file next to the configured history file
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() {
+				_ = os.Remove(path)
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("timed out waiting for another process to release the lock")
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// syncDir does nothing: directories cannot be synced on this platform, and
+// the rename is durable once it returns.
+func syncDir(string) error {
+	return nil
+}
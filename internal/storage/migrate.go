@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SchemaVersion is the version of the article history format written by
+// this build.
+//
+//	1: title, topic, date, URL, tags and optional keywords/references
+//	2: adds stable IDs and content metadata (hash, word count, summary,
+//	   model, prompt template hash, usage, format, archived content path)
+const SchemaVersion = 2
+
+// migrations upgrade a history from version i+1 to i+2.
+var migrations = []func(*ArticleHistory){
+	migrateV1ToV2,
+}
+
+// Migrate upgrades history in place to SchemaVersion and returns the
+// version it was read as. Files without a version are version 1.
+func Migrate(history *ArticleHistory) (int, error) {
+	from := max(history.SchemaVersion, 1)
+	if from > SchemaVersion {
+		return from, fmt.Errorf("article history schema version %d is newer than supported version %d", from, SchemaVersion)
+	}
+	for v := from; v < SchemaVersion; v++ {
+		migrations[v-1](history)
+	}
+	history.SchemaVersion = SchemaVersion
+	return from, nil
+}
+
+// migrateV1ToV2 assigns IDs to records. Content metadata is left empty:
+// version 1 did not keep the published content.
+func migrateV1ToV2(history *ArticleHistory) {
+	for i := range history.Articles {
+		a := &history.Articles[i]
+		if a.ID == "" {
+			a.ID = RecordID(a.Title, a.PublishedAt)
+		}
+	}
+}
+
+// RecordID derives a stable article ID from its title and publication
+// time, so migrating the same history twice gives the same IDs.
+func RecordID(title string, publishedAt time.Time) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + publishedAt.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ContentHash returns the "sha256:<hex>" hash of content.
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
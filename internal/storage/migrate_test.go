@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrate(t *testing.T) {
+	published := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	history := &ArticleHistory{Articles: []ArticleRecord{
+		{Title: "Go Channels", PublishedAt: published},
+		{ID: "kept", Title: "Other"},
+	}}
+
+	from, err := Migrate(history)
+	if err != nil || from != 1 {
+		t.Fatalf("Migrate() = %d, %v, want 1", from, err)
+	}
+	if history.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", history.SchemaVersion, SchemaVersion)
+	}
+	if got := history.Articles[0].ID; got != RecordID("Go Channels", published) || len(got) != 16 {
+		t.Errorf("ID = %q, want a stable 16-character ID", got)
+	}
+	if history.Articles[1].ID != "kept" {
+		t.Errorf("existing ID changed to %q", history.Articles[1].ID)
+	}
+
+	if from, err := Migrate(history); err != nil || from != SchemaVersion {
+		t.Errorf("Migrate() of current history = %d, %v", from, err)
+	}
+	if _, err := Migrate(&ArticleHistory{SchemaVersion: SchemaVersion + 1}); err == nil {
+		t.Error("Migrate() should reject a newer schema version")
+	}
+}
+
+func TestJSONStoreLoad_MigratesFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	storePath := filepath.Join(tmpDir, "articles.json")
+	legacy := `{"articles": [{"title": "Go Channels", "topic": "Go", "published_at": "2025-03-01T09:00:00Z", "url": "https://medium.com/x", "tags": ["go"]}]}`
+	if err := os.WriteFile(storePath, []byte(legacy), 0600); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+
+	history, err := NewJSONStore(storePath).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if history.Articles[0].ID == "" {
+		t.Error("Load() should assign IDs to legacy records")
+	}
+
+	// #nosec G304 -- test-controlled file paths
+	backup, err := os.ReadFile(storePath + ".v1.bak")
+	if err != nil || string(backup) != legacy {
+		t.Errorf("backup = %q, %v, want the original file", backup, err)
+	}
+	// #nosec G304 -- test-controlled file paths
+	rewritten, _ := os.ReadFile(storePath)
+	if !strings.Contains(string(rewritten), `"schema_version": 2`) || !strings.Contains(string(rewritten), history.Articles[0].ID) {
+		t.Errorf("history file was not rewritten:\n%s", rewritten)
+	}
+}
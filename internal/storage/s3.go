@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/s3"
+)
+
+// maxUpdateAttempts bounds how often an S3 update is retried after another
+// writer changed the history between its read and its write.
+const maxUpdateAttempts = 5
+
+// S3Store keeps the article history as a JSON object in an S3-compatible
+// bucket. Writes are conditional on the object's ETag, so a concurrent
+// writer's records are never overwritten.
+type S3Store struct {
+	client *s3.Client
+	key    string
+
+	mu     sync.Mutex
+	loaded bool   // Whether etag reflects a read or write by this store
+	etag   string // ETag of the history last seen; empty if there was none
+}
+
+// NewS3Store creates a store keeping the history in the object key.
+func NewS3Store(client *s3.Client, key string) *S3Store {
+	return &S3Store{client: client, key: key}
+}
+
+// newS3Client creates a client for the configured bucket.
+func newS3Client(cfg config.S3Config) (*s3.Client, error) {
+	return s3.New(s3.Config{
+		Endpoint:        cfg.Endpoint,
+		Region:          cfg.Region,
+		Bucket:          cfg.Bucket,
+		PathStyle:       cfg.PathStyle,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		SessionToken:    cfg.SessionToken,
+	})
+}
+
+// Load reads the history object. Older schemas are migrated in memory and
+// written back by the next update.
+func (s *S3Store) Load() (*ArticleHistory, error) {
+	history, etag, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	s.remember(etag)
+	return history, nil
+}
+
+// read fetches and parses the history object and returns its ETag.
+func (s *S3Store) read() (*ArticleHistory, string, error) {
+	data, etag, err := s.client.Get(context.Background(), s.key)
+	if errors.Is(err, s3.ErrNotFound) {
+		return &ArticleHistory{SchemaVersion: SchemaVersion, Articles: []ArticleRecord{}}, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var history ArticleHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", s.key, err)
+	}
+	if _, err := Migrate(&history); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", s.key, err)
+	}
+	return &history, etag, nil
+}
+
+// write stores the history under the conditions of opts.
+func (s *S3Store) write(history *ArticleHistory, opts s3.PutOptions) error {
+	history.SchemaVersion = SchemaVersion
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	opts.ContentType = "application/json"
+	etag, err := s.client.Put(context.Background(), s.key, data, opts)
+	if err != nil {
+		return err
+	}
+	s.remember(etag)
+	return nil
+}
+
+// unchangedSince makes a write conditional on the object still having the
+// given ETag, or on it not existing yet when etag is empty.
+func unchangedSince(etag string) s3.PutOptions {
+	return s3.PutOptions{IfMatch: etag, IfNoneMatch: etag == ""}
+}
+
+func (s *S3Store) remember(etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded, s.etag = true, etag
+}
+
+// Save replaces the history. If this store has read the history before,
+// the write fails with ErrConflict when another writer changed it since.
+func (s *S3Store) Save(history *ArticleHistory) error {
+	s.mu.Lock()
+	loaded, etag := s.loaded, s.etag
+	s.mu.Unlock()
+
+	var opts s3.PutOptions // Nothing read to protect: replace whatever is there
+	if loaded {
+		opts = unchangedSince(etag)
+	}
+	err := s.write(history, opts)
+	if errors.Is(err, s3.ErrPreconditionFailed) {
+		return fmt.Errorf("%w: %s", ErrConflict, s.key)
+	}
+	return err
+}
+
+// Append adds an article to the history object.
+func (s *S3Store) Append(record ArticleRecord) error {
+	return s.Update(func(history *ArticleHistory) error {
+		return appendRecord(history, record)
+	})
+}
+
+// Get returns the article with the given ID.
+func (s *S3Store) Get(id string) (ArticleRecord, error) {
+	history, err := s.Load()
+	if err != nil {
+		return ArticleRecord{}, err
+	}
+	return findRecord(history, id)
+}
+
+// Query returns the matching articles, newest first.
+func (s *S3Store) Query(q Query) ([]ArticleRecord, error) {
+	history, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(history.Articles), nil
+}
+
+// Update reads the history, applies change and writes the result on the
+// condition that nobody else wrote in between. When someone did, the
+// update is retried on the fresh history.
+func (s *S3Store) Update(change func(*ArticleHistory) error) error {
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		history, etag, err := s.read()
+		if err != nil {
+			return err
+		}
+		if err := change(history); err != nil {
+			return err
+		}
+		err = s.write(history, unchangedSince(etag))
+		if !errors.Is(err, s3.ErrPreconditionFailed) {
+			return err
+		}
+		slog.Info("Article history changed concurrently, retrying update", "key", s.key, "attempt", attempt)
+	}
+	return fmt.Errorf("%w: %s after %d attempts", ErrConflict, s.key, maxUpdateAttempts)
+}
+
+// Close does nothing; requests hold no connection open.
+func (s *S3Store) Close() error {
+	return nil
+}
+
+// S3Archive keeps each published article under its own prefix,
+// <prefix>articles/<id>/, as article.md with its record as record.json.
+type S3Archive struct {
+	client *s3.Client
+	prefix string
+}
+
+// NewS3Archive creates an archive under prefix in the client's bucket.
+func NewS3Archive(client *s3.Client, prefix string) *S3Archive {
+	return &S3Archive{client: client, prefix: prefix}
+}
+
+// Put uploads the content of an article and its record, and records the
+// content's location as s3://<bucket>/<key>.
+func (a *S3Archive) Put(record *ArticleRecord, content string) error {
+	if record.ID == "" {
+		return fmt.Errorf("article %q has no ID", record.Title)
+	}
+	dir := a.prefix + "articles/" + record.ID + "/"
+	ctx := context.Background()
+	if _, err := a.client.Put(ctx, dir+"article.md", []byte(content), s3.PutOptions{ContentType: "text/markdown; charset=utf-8"}); err != nil {
+		return err
+	}
+	describeContent(record, "s3://"+a.client.Bucket()+"/"+dir+"article.md", content)
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = a.client.Put(ctx, dir+"record.json", data, s3.PutOptions{ContentType: "application/json"})
+	return err
+}
+
+// Get downloads the archived content of an article, checking its hash.
+func (a *S3Archive) Get(record ArticleRecord) (string, error) {
+	key, ok := strings.CutPrefix(record.ContentPath, "s3://"+a.client.Bucket()+"/")
+	if !ok {
+		return "", fmt.Errorf("article %q has no content archived in bucket %s", record.Title, a.client.Bucket())
+	}
+	data, _, err := a.client.Get(context.Background(), key)
+	if err != nil {
+		return "", err
+	}
+	return verifyContent(record, data)
+}
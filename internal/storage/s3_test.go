@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/s3"
+	"github.com/yourusername/autoblog-ai/internal/s3/s3test"
+)
+
+func newTestS3Client(t *testing.T) (*s3.Client, *s3test.Server) {
+	t.Helper()
+	server := s3test.NewServer("blog")
+	t.Cleanup(server.Close)
+	client, err := newS3Client(config.S3Config{
+		Endpoint:        server.URL,
+		Bucket:          "blog",
+		PathStyle:       true,
+		AccessKeyID:     s3test.AccessKeyID,
+		SecretAccessKey: s3test.SecretAccessKey,
+	})
+	if err != nil {
+		t.Fatalf("newS3Client() error = %v", err)
+	}
+	return client, server
+}
+
+func TestS3Store_Contract(t *testing.T) {
+	testStoreContract(t, func(t *testing.T) Store {
+		client, _ := newTestS3Client(t)
+		return NewS3Store(client, "autoblog/articles.json")
+	})
+}
+
+func TestS3Store_UpdateRetriesOnConflict(t *testing.T) {
+	client, server := newTestS3Client(t)
+	store := NewS3Store(client, "articles.json")
+	if err := store.Append(contractRecord("a1", "Go", 0)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// Another run appends between this update's read and write
+	other := NewS3Store(client, "articles.json")
+	attempts := 0
+	err := store.Update(func(h *ArticleHistory) error {
+		attempts++
+		if attempts == 1 {
+			if err := other.Append(contractRecord("b1", "AI", 1)); err != nil {
+				t.Fatalf("concurrent Append() error = %v", err)
+			}
+		}
+		h.Articles = append(h.Articles, contractRecord("a2", "Go", 2))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("change applied %d times, want 2 (retried once)", attempts)
+	}
+
+	data, _ := server.Object("articles.json")
+	var history ArticleHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.Fatalf("stored history: %v", err)
+	}
+	var ids []string
+	for _, a := range history.Articles {
+		ids = append(ids, a.ID)
+	}
+	if want := []string{"a1", "b1", "a2"}; !slices.Equal(ids, want) {
+		t.Errorf("stored articles = %v, want %v: the concurrent append was lost", ids, want)
+	}
+}
+
+func TestS3Store_SaveConflict(t *testing.T) {
+	client, server := newTestS3Client(t)
+	store := NewS3Store(client, "articles.json")
+	history, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	server.SetObject("articles.json", []byte(`{"schema_version":2,"articles":[]}`))
+	history.Articles = append(history.Articles, contractRecord("a1", "Go", 0))
+	if err := store.Save(history); !errors.Is(err, ErrConflict) {
+		t.Errorf("Save() over a concurrent write error = %v, want ErrConflict", err)
+	}
+
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := store.Save(history); err != nil {
+		t.Errorf("Save() after reloading error = %v", err)
+	}
+}
+
+func TestS3Archive(t *testing.T) {
+	client, server := newTestS3Client(t)
+	archive := NewS3Archive(client, "autoblog/")
+
+	record := contractRecord("a1", "Go", 0)
+	record.ContentPath, record.ContentHash, record.WordCount = "", "", 0
+	if err := archive.Put(&record, "Some archived words"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if record.ContentPath != "s3://blog/autoblog/articles/a1/article.md" || record.WordCount != 3 || record.ContentHash == "" {
+		t.Errorf("Put() recorded path %q, %d words, hash %q", record.ContentPath, record.WordCount, record.ContentHash)
+	}
+
+	data, ok := server.Object("autoblog/articles/a1/record.json")
+	var stored ArticleRecord
+	if !ok || json.Unmarshal(data, &stored) != nil || stored.ContentHash != record.ContentHash {
+		t.Errorf("record.json = %s, want the record with its content hash", data)
+	}
+
+	if got, err := archive.Get(record); err != nil || got != "Some archived words" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	server.SetObject("autoblog/articles/a1/article.md", []byte("tampered"))
+	if _, err := archive.Get(record); err == nil {
+		t.Error("Get() should fail when the content no longer matches its hash")
+	}
+	if _, err := archive.Get(ArticleRecord{Title: "local", ContentPath: "archive/x.md"}); err == nil {
+		t.Error("Get() should fail for content archived elsewhere")
+	}
+	if err := archive.Put(&ArticleRecord{Title: "no id"}, "x"); err == nil {
+		t.Error("Put() should require an ID")
+	}
+}
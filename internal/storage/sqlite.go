@@ -0,0 +1,480 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	// Pure-Go SQLite driver, so static CGO_ENABLED=0 builds keep working
+	_ "modernc.org/sqlite"
+)
+
+// sqliteMigrations create and upgrade the schema. The number of migrations
+// applied is kept in PRAGMA user_version; append new steps, never edit old ones.
+var sqliteMigrations = []string{
+	`CREATE TABLE articles (
+		id           TEXT PRIMARY KEY,
+		seq          INTEGER NOT NULL UNIQUE, -- insertion order
+		title        TEXT NOT NULL,
+		topic        TEXT NOT NULL,
+		category     TEXT NOT NULL DEFAULT '',
+		published_at TEXT NOT NULL,           -- RFC 3339, as recorded
+		published_ns INTEGER NOT NULL,        -- Unix nanoseconds, for range queries
+		keywords     TEXT NOT NULL DEFAULT 'null',
+		refs         TEXT NOT NULL DEFAULT 'null',
+		fingerprint  TEXT NOT NULL DEFAULT 'null',
+		content_hash TEXT NOT NULL DEFAULT '',
+		word_count   INTEGER NOT NULL DEFAULT 0,
+		summary      TEXT NOT NULL DEFAULT '',
+		prompt_hash  TEXT NOT NULL DEFAULT '',
+		format       TEXT NOT NULL DEFAULT '',
+		content_path TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX articles_topic ON articles (topic, published_ns);
+	CREATE INDEX articles_category ON articles (category, published_ns);
+	CREATE INDEX articles_published ON articles (published_ns);
+
+	CREATE TABLE tags (
+		article_id TEXT NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+		position   INTEGER NOT NULL,
+		tag        TEXT NOT NULL,
+		tag_key    TEXT NOT NULL, -- lower-cased tag
+		PRIMARY KEY (article_id, position)
+	);
+	CREATE INDEX tags_tag ON tags (tag_key);
+
+	CREATE TABLE usage (
+		article_id    TEXT PRIMARY KEY REFERENCES articles (id) ON DELETE CASCADE,
+		model         TEXT NOT NULL DEFAULT '',
+		input_tokens  INTEGER,
+		output_tokens INTEGER
+	);
+
+	CREATE TABLE destinations (
+		article_id TEXT NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+		platform   TEXT NOT NULL,
+		url        TEXT NOT NULL,
+		PRIMARY KEY (article_id, platform)
+	);`,
+	`ALTER TABLE articles ADD COLUMN status TEXT NOT NULL DEFAULT ''; -- empty once published
+	ALTER TABLE articles ADD COLUMN review_note TEXT NOT NULL DEFAULT '';
+	CREATE INDEX articles_status ON articles (status, published_ns);`,
+}
+
+// sqliteDestination is the platform of ArticleRecord.URL in the
+// destinations table.
+const sqliteDestination = "medium"
+
+// SQLiteStore keeps the article history in an SQLite database with indexed
+// topic, tag and date queries.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens or creates the database at path and brings its
+// schema up to date.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	// Transactions take the write lock when they begin, so a process that
+	// reads and then writes in Update waits for another one's commit rather
+	// than failing with SQLITE_BUSY when it tries to upgrade its lock.
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	// Serialize access: one connection avoids SQLITE_BUSY between our own
+	// transactions and keeps the pragmas in effect.
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) migrate() error {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(sqliteMigrations))
+	}
+	for v := version; v < len(sqliteMigrations); v++ {
+		err := s.inTx(func(tx *sql.Tx) error {
+			if _, err := tx.Exec(sqliteMigrations[v]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", v+1))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", v+1, err)
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, committing when it returns nil.
+func (s *SQLiteStore) inTx(fn func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Load returns every article in insertion order.
+func (s *SQLiteStore) Load() (*ArticleHistory, error) {
+	articles, err := selectArticles(s.db, "ORDER BY a.seq")
+	if err != nil {
+		return nil, err
+	}
+	if articles == nil {
+		articles = []ArticleRecord{}
+	}
+	return &ArticleHistory{SchemaVersion: SchemaVersion, Articles: articles}, nil
+}
+
+// Save replaces every article.
+func (s *SQLiteStore) Save(history *ArticleHistory) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		return replaceArticles(tx, history.Articles)
+	})
+}
+
+// Append inserts one article.
+func (s *SQLiteStore) Append(record ArticleRecord) error {
+	if record.ID == "" {
+		record.ID = RecordID(record.Title, record.PublishedAt)
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM articles WHERE id = ?)", record.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: %s", ErrExists, record.ID)
+		}
+		return writeArticle(tx, record)
+	})
+}
+
+// Get returns the article with the given ID.
+func (s *SQLiteStore) Get(id string) (ArticleRecord, error) {
+	articles, err := selectArticles(s.db, "WHERE a.id = ?", id)
+	if err != nil {
+		return ArticleRecord{}, err
+	}
+	if len(articles) == 0 {
+		return ArticleRecord{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return articles[0], nil
+}
+
+// Query returns the matching articles, newest first, using the topic,
+// category, tag and date indexes.
+func (s *SQLiteStore) Query(q Query) ([]ArticleRecord, error) {
+	var (
+		where []string
+		args  []any
+	)
+	if q.Topic != "" {
+		where, args = append(where, "a.topic = ?"), append(args, q.Topic)
+	}
+	if q.Category != "" {
+		where, args = append(where, "a.category = ?"), append(args, q.Category)
+	}
+	if q.Tag != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM tags t WHERE t.article_id = a.id AND t.tag_key = ?)")
+		args = append(args, strings.ToLower(q.Tag))
+	}
+	if !q.Since.IsZero() {
+		where, args = append(where, "a.published_ns >= ?"), append(args, q.Since.UnixNano())
+	}
+	if !q.Until.IsZero() {
+		where, args = append(where, "a.published_ns < ?"), append(args, q.Until.UnixNano())
+	}
+	if q.Status != "" {
+		status := q.Status
+		if status == StatusPublished {
+			status = ""
+		}
+		where, args = append(where, "a.status = ?"), append(args, status)
+	}
+
+	clause := ""
+	if len(where) > 0 {
+		clause = "WHERE " + strings.Join(where, " AND ")
+	}
+	clause += " ORDER BY a.published_ns DESC, a.seq"
+	if q.Limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d", q.Limit)
+	}
+	return selectArticles(s.db, clause, args...)
+}
+
+// Update applies change to the whole history within one transaction, and
+// writes only the articles it added, changed or removed.
+func (s *SQLiteStore) Update(change func(*ArticleHistory) error) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		articles, err := selectArticles(tx, "ORDER BY a.seq")
+		if err != nil {
+			return err
+		}
+		// change may modify the records in place; remember how they were
+		before := make(map[string]string, len(articles))
+		order := make([]string, 0, len(articles))
+		for _, a := range articles {
+			data, err := json.Marshal(a)
+			if err != nil {
+				return err
+			}
+			before[a.ID] = string(data)
+			order = append(order, a.ID)
+		}
+
+		history := &ArticleHistory{SchemaVersion: SchemaVersion, Articles: articles}
+		if err := change(history); err != nil {
+			return err
+		}
+		return updateArticles(tx, before, order, history.Articles)
+	})
+}
+
+// updateArticles writes the difference between the articles before a
+// change, by ID in insertion order, and after it. Reordering existing
+// articles rewrites them all, since the order is kept in their seq.
+func updateArticles(tx *sql.Tx, before map[string]string, order []string, articles []ArticleRecord) error {
+	seen := make(map[string]bool, len(articles))
+	var kept []string // IDs of the remaining articles, in their new order
+	for i := range articles {
+		a := &articles[i]
+		if a.ID == "" {
+			a.ID = RecordID(a.Title, a.PublishedAt)
+		}
+		if seen[a.ID] {
+			return fmt.Errorf("%w: %s", ErrExists, a.ID)
+		}
+		seen[a.ID] = true
+		if _, ok := before[a.ID]; ok {
+			kept = append(kept, a.ID)
+		}
+	}
+	remaining := slices.DeleteFunc(slices.Clone(order), func(id string) bool { return !seen[id] })
+	if !slices.Equal(remaining, kept) {
+		return replaceArticles(tx, articles)
+	}
+
+	for _, id := range order {
+		if seen[id] {
+			continue
+		}
+		// Tags, usage and destinations go with it (ON DELETE CASCADE)
+		if _, err := tx.Exec("DELETE FROM articles WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	for _, a := range articles {
+		previous, ok := before[a.ID]
+		if ok {
+			data, err := json.Marshal(a)
+			if err != nil {
+				return err
+			}
+			if string(data) == previous {
+				continue
+			}
+		}
+		if err := writeArticle(tx, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// selectArticles reads the articles matching clause, with their tags,
+// usage and destination.
+func selectArticles(db querier, clause string, args ...any) ([]ArticleRecord, error) {
+	rows, err := db.Query(`SELECT a.id, a.title, a.topic, a.category, a.published_at, a.keywords, a.refs,
+		a.fingerprint, a.content_hash, a.word_count, a.summary, a.prompt_hash, a.format, a.content_path,
+		a.status, a.review_note, COALESCE(d.url, ''), COALESCE(u.model, ''), u.input_tokens, u.output_tokens
+		FROM articles a
+		LEFT JOIN destinations d ON d.article_id = a.id AND d.platform = '`+sqliteDestination+`'
+		LEFT JOIN usage u ON u.article_id = a.id
+		`+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		articles []ArticleRecord
+		index    = make(map[string]int)
+	)
+	for rows.Next() {
+		var (
+			a                           ArticleRecord
+			published                   string
+			keywords, refs, fingerprint string
+			inputTokens, outputTokens   sql.NullInt64
+		)
+		err := rows.Scan(&a.ID, &a.Title, &a.Topic, &a.Category, &published, &keywords, &refs,
+			&fingerprint, &a.ContentHash, &a.WordCount, &a.Summary, &a.PromptHash, &a.Format, &a.ContentPath,
+			&a.Status, &a.ReviewNote, &a.URL, &a.Model, &inputTokens, &outputTokens)
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		if a.PublishedAt, err = time.Parse(time.RFC3339Nano, published); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("article %s: %w", a.ID, err)
+		}
+		err = errors.Join(
+			json.Unmarshal([]byte(keywords), &a.Keywords),
+			json.Unmarshal([]byte(refs), &a.References),
+			json.Unmarshal([]byte(fingerprint), &a.Fingerprint),
+		)
+		if err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("article %s: %w", a.ID, err)
+		}
+		if inputTokens.Valid {
+			a.Usage = &Usage{InputTokens: int(inputTokens.Int64), OutputTokens: int(outputTokens.Int64)}
+		}
+		index[a.ID] = len(articles)
+		articles = append(articles, a)
+	}
+	if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return articles, nil
+	}
+
+	ids, err := json.Marshal(slices.Collect(maps.Keys(index)))
+	if err != nil {
+		return nil, err
+	}
+	tags, err := db.Query("SELECT article_id, tag FROM tags WHERE article_id IN (SELECT value FROM json_each(?)) ORDER BY article_id, position", string(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tags.Close()
+	}()
+	for tags.Next() {
+		var id, tag string
+		if err := tags.Scan(&id, &tag); err != nil {
+			return nil, err
+		}
+		if i, ok := index[id]; ok {
+			articles[i].Tags = append(articles[i].Tags, tag)
+		}
+	}
+	return articles, tags.Err()
+}
+
+// replaceArticles deletes every article and inserts articles in order.
+func replaceArticles(tx *sql.Tx, articles []ArticleRecord) error {
+	if _, err := tx.Exec("DELETE FROM articles"); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(articles))
+	for _, a := range articles {
+		if a.ID == "" {
+			a.ID = RecordID(a.Title, a.PublishedAt)
+		}
+		if seen[a.ID] {
+			return fmt.Errorf("%w: %s", ErrExists, a.ID)
+		}
+		seen[a.ID] = true
+		if err := writeArticle(tx, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArticle inserts an article, or updates it in place keeping its
+// insertion order, and replaces its tags, usage and destination.
+func writeArticle(tx *sql.Tx, a ArticleRecord) error {
+	keywords, err := json.Marshal(a.Keywords)
+	if err != nil {
+		return err
+	}
+	refs, err := json.Marshal(a.References)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := json.Marshal(a.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO articles (id, seq, title, topic, category, published_at, published_ns,
+		keywords, refs, fingerprint, content_hash, word_count, summary, prompt_hash, format, content_path, status, review_note)
+		VALUES (?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM articles), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET title = excluded.title, topic = excluded.topic, category = excluded.category,
+			published_at = excluded.published_at, published_ns = excluded.published_ns, keywords = excluded.keywords,
+			refs = excluded.refs, fingerprint = excluded.fingerprint, content_hash = excluded.content_hash,
+			word_count = excluded.word_count, summary = excluded.summary, prompt_hash = excluded.prompt_hash,
+			format = excluded.format, content_path = excluded.content_path, status = excluded.status,
+			review_note = excluded.review_note`,
+		a.ID, a.Title, a.Topic, a.Category, a.PublishedAt.Format(time.RFC3339Nano), a.PublishedAt.UnixNano(),
+		string(keywords), string(refs), string(fingerprint), a.ContentHash, a.WordCount, a.Summary, a.PromptHash, a.Format, a.ContentPath, a.Status, a.ReviewNote)
+	if err != nil {
+		return err
+	}
+	for _, table := range []string{"tags", "usage", "destinations"} {
+		// #nosec G202 -- table is one of the names above
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE article_id = ?", a.ID); err != nil {
+			return err
+		}
+	}
+
+	for i, tag := range a.Tags {
+		if _, err := tx.Exec("INSERT INTO tags (article_id, position, tag, tag_key) VALUES (?, ?, ?, ?)",
+			a.ID, i, tag, strings.ToLower(tag)); err != nil {
+			return err
+		}
+	}
+	if a.Usage != nil || a.Model != "" {
+		var input, output sql.NullInt64
+		if a.Usage != nil {
+			input = sql.NullInt64{Int64: int64(a.Usage.InputTokens), Valid: true}
+			output = sql.NullInt64{Int64: int64(a.Usage.OutputTokens), Valid: true}
+		}
+		if _, err := tx.Exec("INSERT INTO usage (article_id, model, input_tokens, output_tokens) VALUES (?, ?, ?, ?)",
+			a.ID, a.Model, input, output); err != nil {
+			return err
+		}
+	}
+	if a.URL != "" {
+		if _, err := tx.Exec("INSERT INTO destinations (article_id, platform, url) VALUES (?, ?, ?)",
+			a.ID, sqliteDestination, a.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
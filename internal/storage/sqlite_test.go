@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestSQLiteStore(t *testing.T, path string) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store
+}
+
+func TestSQLiteStore_Contract(t *testing.T) {
+	testStoreContract(t, func(t *testing.T) Store {
+		return newTestSQLiteStore(t, filepath.Join(t.TempDir(), "articles.db"))
+	})
+}
+
+func TestSQLiteStore_Migrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.db")
+	store := newTestSQLiteStore(t, path)
+
+	var version int
+	if err := store.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("reading user_version: %v", err)
+	}
+	if version != len(sqliteMigrations) {
+		t.Errorf("user_version = %d, want %d", version, len(sqliteMigrations))
+	}
+	if err := store.Append(contractRecord("a1", "Go", 0, "go")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Reopening an up-to-date database keeps its data
+	reopened := newTestSQLiteStore(t, path)
+	if _, err := reopened.Get("a1"); err != nil {
+		t.Errorf("Get() after reopening error = %v", err)
+	}
+
+	// A database written by a newer version is refused
+	if _, err := reopened.db.Exec("PRAGMA user_version = 99"); err != nil {
+		t.Fatalf("setting user_version: %v", err)
+	}
+	_ = reopened.Close()
+	if _, err := NewSQLiteStore(path); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("NewSQLiteStore() on a newer schema error = %v", err)
+	}
+}
+
+func TestSQLiteStore_UpgradeKeepsArticles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A database created before drafts had a review status
+	for _, stmt := range []string{
+		sqliteMigrations[0],
+		"PRAGMA user_version = 1",
+		`INSERT INTO articles (id, seq, title, topic, published_at, published_ns)
+			VALUES ('old', 1, 'Old', 'Go', '2024-01-02T03:04:05Z', 1704164645000000000)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	_ = db.Close()
+
+	store := newTestSQLiteStore(t, path)
+	a, err := store.Get("old")
+	if err != nil {
+		t.Fatalf("Get() after upgrade error = %v", err)
+	}
+	if !a.Published() {
+		t.Errorf("upgraded article status = %q, want published", a.Status)
+	}
+}
+
+func TestSQLiteStore_Tables(t *testing.T) {
+	store := newTestSQLiteStore(t, filepath.Join(t.TempDir(), "articles.db"))
+	record := contractRecord("a1", "Go", 0, "Go", "testing")
+	if err := store.Append(record); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT group_concat(tag_key, ',') FROM tags WHERE article_id = 'a1'", "go,testing"},
+		{"SELECT url FROM destinations WHERE article_id = 'a1' AND platform = 'medium'", record.URL},
+		{"SELECT model || ':' || input_tokens || ':' || output_tokens FROM usage WHERE article_id = 'a1'", "claude-sonnet-4-20250514:900:2100"},
+	}
+	for _, tt := range tests {
+		var got string
+		if err := store.db.QueryRow(tt.query).Scan(&got); err != nil {
+			t.Errorf("%s: %v", tt.query, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	// Replacing the history removes the rows of dropped articles
+	if err := store.Save(&ArticleHistory{}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	for _, table := range []string{"tags", "destinations", "usage"} {
+		var n int
+		if err := store.db.QueryRow("SELECT count(*) FROM " + table).Scan(&n); err != nil || n != 0 {
+			t.Errorf("%s has %d rows after Save() (err %v), want 0", table, n, err)
+		}
+	}
+}
+
+func TestSQLiteStore_UpdateWritesChanges(t *testing.T) {
+	store := newTestSQLiteStore(t, filepath.Join(t.TempDir(), "articles.db"))
+	history := &ArticleHistory{}
+	for i := range 20 {
+		history.Articles = append(history.Articles, contractRecord(fmt.Sprintf("a%02d", i), "Go", i, "go", "testing"))
+	}
+	if err := store.Save(history); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	changes := func() int {
+		var n int
+		if err := store.db.QueryRow("SELECT total_changes()").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	start := changes()
+	err := store.Update(func(h *ArticleHistory) error {
+		h.Articles[3].Status = StatusPublishing
+		h.Articles[3].Tags = []string{"go"}
+		h.Articles = append(h.Articles[:7], h.Articles[8:]...)
+		h.Articles = append(h.Articles, contractRecord("new", "AI", 30, "ai"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// a03: row, 2 tags, usage, destination and their rewrite; a07 and its
+	// children; the new article. A full rewrite would touch every row.
+	if n := changes() - start; n > 20 {
+		t.Errorf("Update() changed %d rows, want only those of the three articles", n)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var ids []string
+	for _, a := range got.Articles {
+		ids = append(ids, a.ID)
+	}
+	if len(ids) != 20 || ids[3] != "a03" || ids[7] != "a08" || ids[19] != "new" {
+		t.Errorf("Load() IDs = %v, want insertion order kept", ids)
+	}
+	if a := got.Articles[3]; a.Status != StatusPublishing || !reflect.DeepEqual(a.Tags, []string{"go"}) {
+		t.Errorf("updated article = %+v", a)
+	}
+	if a, err := store.Get("a04"); err != nil || !reflect.DeepEqual(a.Tags, []string{"go", "testing"}) {
+		t.Errorf("Get(a04) tags = %v, %v", a.Tags, err)
+	}
+
+	// Reordering falls back to rewriting every article
+	err = store.Update(func(h *ArticleHistory) error {
+		h.Articles[0], h.Articles[1] = h.Articles[1], h.Articles[0]
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got, err = store.Load(); err != nil || got.Articles[0].ID != "a01" || got.Articles[1].ID != "a00" {
+		t.Errorf("Load() after reordering starts with %s, %s", got.Articles[0].ID, got.Articles[1].ID)
+	}
+}
+
+func TestSQLiteStore_ConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.db")
+	stores := []*SQLiteStore{newTestSQLiteStore(t, path), newTestSQLiteStore(t, path)}
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- stores[i%2].Update(func(h *ArticleHistory) error {
+				h.Articles = append(h.Articles, contractRecord(fmt.Sprintf("a%02d", i), "Go", i))
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Update() error = %v", err)
+		}
+	}
+	got, err := stores[0].Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got.Articles) != n {
+		t.Errorf("Load() = %d articles, want %d", len(got.Articles), n)
+	}
+}
@@ -2,26 +2,131 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 )
 
 // ArticleHistory contains a collection of published articles.
 type ArticleHistory struct {
-	Articles []ArticleRecord `json:"articles"`
+	SchemaVersion int             `json:"schema_version"`
+	Articles      []ArticleRecord `json:"articles"`
 }
 
 // ArticleRecord represents a single published article.
 type ArticleRecord struct {
-	Title       string    `json:"title"`
-	Topic       string    `json:"topic"`
-	PublishedAt time.Time `json:"published_at"`
-	URL         string    `json:"url"`
-	Tags        []string  `json:"tags"`
+	ID          string      `json:"id"` // Stable identifier, see RecordID
+	Title       string      `json:"title"`
+	Topic       string      `json:"topic"`
+	Category    string      `json:"category,omitempty"`
+	PublishedAt time.Time   `json:"published_at"`
+	URL         string      `json:"url"`
+	Tags        []string    `json:"tags"`
+	Keywords    []string    `json:"keywords,omitempty"` // Topic keywords the article covered
+	References  []Reference `json:"references,omitempty"`
+	Fingerprint []uint32    `json:"fingerprint,omitempty"` // MinHash signature of title and body for near-duplicate checks
+
+	// Content metadata, recorded from schema version 2
+	ContentHash string `json:"content_hash,omitempty"` // "sha256:<hex>" of the archived content
+	WordCount   int    `json:"word_count,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Model       string `json:"model,omitempty"`
+	PromptHash  string `json:"prompt_template_hash,omitempty"` // "sha256:<hex>" of the prompt template used
+	Usage       *Usage `json:"usage,omitempty"`
+	Format      string `json:"format,omitempty"`       // Editorial format, e.g. "tutorial"
+	ContentPath string `json:"content_path,omitempty"` // Archived copy of the published content
+
+	// Review state of generated drafts, see ReviewStatus
+	Status     string `json:"status,omitempty"`      // Empty once published
+	ReviewNote string `json:"review_note,omitempty"` // Reason given when rejected
+}
+
+// Review statuses of an article. Drafts are generated as pending, approved
+// or rejected by a reviewer, and published once approved. An article is
+// publishing from just before it is sent to the publisher until the result
+// is recorded, so an interrupted publish can be reconciled.
+const (
+	StatusPending    = "pending"
+	StatusApproved   = "approved"
+	StatusRejected   = "rejected"
+	StatusPublishing = "publishing"
+	StatusPublished  = "published"
+)
+
+// ReviewStatus returns the article's review status. Records without one
+// were published directly, before review existed or without it.
+func (a ArticleRecord) ReviewStatus() string {
+	if a.Status == "" {
+		return StatusPublished
+	}
+	return a.Status
+}
+
+// Published reports whether the article was published, as opposed to a
+// draft in review.
+func (a ArticleRecord) Published() bool {
+	return a.Status == ""
+}
+
+// Usage is the model token usage of generating an article.
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Reference is a source document passage cited by a published article.
+type Reference struct {
+	Document  string `json:"document"`
+	CitedText string `json:"cited_text"`
+	Location  string `json:"location,omitempty"`
+}
+
+// KeywordCoverage counts how many articles on topic covered each keyword.
+// Keys are lower-cased.
+func (h *ArticleHistory) KeywordCoverage(topic string) map[string]int {
+	coverage := make(map[string]int)
+	for _, a := range h.Articles {
+		if a.Topic != topic {
+			continue
+		}
+		for _, kw := range a.Keywords {
+			coverage[strings.ToLower(kw)]++
+		}
+	}
+	return coverage
 }
 
-// JSONStore manages article history persistence in JSON format.
+// WithoutRejected returns a copy of the history without rejected drafts,
+// for checks that should count what is published or about to be.
+func (h *ArticleHistory) WithoutRejected() *ArticleHistory {
+	kept := &ArticleHistory{SchemaVersion: h.SchemaVersion, Articles: make([]ArticleRecord, 0, len(h.Articles))}
+	for _, a := range h.Articles {
+		if a.Status != StatusRejected {
+			kept.Articles = append(kept.Articles, a)
+		}
+	}
+	return kept
+}
+
+// PublishedOnly returns a copy of the history without drafts.
+func (h *ArticleHistory) PublishedOnly() *ArticleHistory {
+	kept := &ArticleHistory{SchemaVersion: h.SchemaVersion, Articles: make([]ArticleRecord, 0, len(h.Articles))}
+	for _, a := range h.Articles {
+		if a.Published() {
+			kept.Articles = append(kept.Articles, a)
+		}
+	}
+	return kept
+}
+
+// JSONStore manages article history persistence in JSON format. Writes
+// replace the file atomically, keep the previous versions as numbered
+// backups and hold an advisory lock on <path>.lock, so overlapping runs
+// cannot lose each other's records.
 type JSONStore struct {
 	filepath string
 }
@@ -31,30 +136,141 @@ func NewJSONStore(filepath string) *JSONStore {
 	return &JSONStore{filepath: filepath}
 }
 
-// Load reads the article history from the JSON file.
+// Load reads the article history from the JSON file. Files written with an
+// older schema are migrated and rewritten, keeping the original as a backup.
 func (s *JSONStore) Load() (*ArticleHistory, error) {
+	history, original, from, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if from != SchemaVersion {
+		err := withLock(s.filepath, func() error {
+			// Another process may have migrated or changed the file since
+			// it was read; only rewrite the version that was migrated.
+			current, err := os.ReadFile(s.filepath)
+			if err != nil || !bytes.Equal(current, original) {
+				return err
+			}
+			return s.rewriteMigrated(history, original, from)
+		})
+		if err != nil {
+			slog.Warn("Could not save migrated article history", "path", s.filepath, "error", err)
+		}
+	}
+	return history, nil
+}
+
+// read parses the JSON file and migrates it in memory. It returns the
+// file's content and the schema version it was written with.
+func (s *JSONStore) read() (history *ArticleHistory, original []byte, from int, err error) {
 	data, err := os.ReadFile(s.filepath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &ArticleHistory{Articles: []ArticleRecord{}}, nil
+			return &ArticleHistory{SchemaVersion: SchemaVersion, Articles: []ArticleRecord{}}, nil, SchemaVersion, nil
 		}
-		return nil, err
+		return nil, nil, 0, err
 	}
 
-	var history ArticleHistory
-	if err := json.Unmarshal(data, &history); err != nil {
-		return nil, err
+	history = &ArticleHistory{}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, nil, 0, err
 	}
 
-	return &history, nil
+	from, err = Migrate(history)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("%s: %w", s.filepath, err)
+	}
+	return history, data, from, nil
+}
+
+// rewriteMigrated saves a migrated history after backing up the original
+// file. It must be called with the lock held. Failures leave the migrated
+// history usable, and the migration is retried on the next load.
+func (s *JSONStore) rewriteMigrated(history *ArticleHistory, original []byte, from int) error {
+	backup := fmt.Sprintf("%s.v%d.bak", s.filepath, from)
+	if err := writeFileAtomic(backup, original, 0600); err != nil {
+		return fmt.Errorf("could not back up article history before migration: %w", err)
+	}
+	if err := s.save(history); err != nil {
+		return err
+	}
+	slog.Info("Migrated article history", "path", s.filepath, "from", from, "to", SchemaVersion, "backup", backup)
+	return nil
 }
 
 // Save writes the article history to the JSON file.
 func (s *JSONStore) Save(history *ArticleHistory) error {
+	return withLock(s.filepath, func() error {
+		return s.save(history)
+	})
+}
+
+// save rotates the backups and replaces the file. It must be called with
+// the lock held.
+func (s *JSONStore) save(history *ArticleHistory) error {
+	history.SchemaVersion = SchemaVersion
 	data, err := json.MarshalIndent(history, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(s.filepath, data, 0600)
+	if err := rotateBackups(s.filepath, backupCopies); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", s.filepath, err)
+	}
+	return writeFileAtomic(s.filepath, data, 0600)
+}
+
+// Append adds an article to the history file.
+func (s *JSONStore) Append(record ArticleRecord) error {
+	return s.Update(func(history *ArticleHistory) error {
+		return appendRecord(history, record)
+	})
+}
+
+// Get returns the article with the given ID.
+func (s *JSONStore) Get(id string) (ArticleRecord, error) {
+	history, err := s.Load()
+	if err != nil {
+		return ArticleRecord{}, err
+	}
+	return findRecord(history, id)
+}
+
+// Query returns the matching articles, newest first.
+func (s *JSONStore) Query(q Query) ([]ArticleRecord, error) {
+	history, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(history.Articles), nil
+}
+
+// Close does nothing; the file is only open while it is read or written.
+func (s *JSONStore) Close() error {
+	return nil
+}
+
+// Update loads the history file, applies change and writes the result,
+// holding the lock throughout so that concurrent updates are serialized.
+func (s *JSONStore) Update(change func(*ArticleHistory) error) error {
+	return withLock(s.filepath, func() error {
+		_, err := s.update(change)
+		return err
+	})
+}
+
+// update applies change to the history file and returns the saved history.
+// It must be called with the lock held.
+func (s *JSONStore) update(change func(*ArticleHistory) error) (*ArticleHistory, error) {
+	history, original, from, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if err := change(history); err != nil {
+		return nil, err
+	}
+	if from != SchemaVersion {
+		return history, s.rewriteMigrated(history, original, from)
+	}
+	return history, s.save(history)
 }
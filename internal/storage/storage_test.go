@@ -150,3 +150,16 @@ func TestArticleRecord(t *testing.T) {
 		t.Errorf("PublishedAt mismatch")
 	}
 }
+
+func TestKeywordCoverage(t *testing.T) {
+	history := &ArticleHistory{Articles: []ArticleRecord{
+		{Topic: "Go", Keywords: []string{"Channels", "context"}},
+		{Topic: "Go", Keywords: []string{"channels"}},
+		{Topic: "AI", Keywords: []string{"context"}},
+	}}
+
+	coverage := history.KeywordCoverage("Go")
+	if coverage["channels"] != 2 || coverage["context"] != 1 || len(coverage) != 2 {
+		t.Errorf("KeywordCoverage(Go) = %v", coverage)
+	}
+}
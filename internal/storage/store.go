@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+var (
+	// ErrNotFound is returned when no article has the requested ID.
+	ErrNotFound = errors.New("article not found")
+	// ErrExists is returned when appending an article whose ID is taken.
+	ErrExists = errors.New("article already exists")
+	// ErrConflict is returned when a write is refused because another
+	// writer changed the history since it was read.
+	ErrConflict = errors.New("article history changed concurrently")
+)
+
+// Store persists the article history. Every backend must pass the contract
+// tests in contract_test.go.
+type Store interface {
+	// Load returns the whole history; a store that was never written is
+	// empty, not an error.
+	Load() (*ArticleHistory, error)
+	// Save replaces the whole history.
+	Save(history *ArticleHistory) error
+	// Append adds one article. An empty ID is filled in with RecordID;
+	// an ID that is already stored fails with ErrExists.
+	Append(record ArticleRecord) error
+	// Get returns the article with the given ID, or ErrNotFound.
+	Get(id string) (ArticleRecord, error)
+	// Query returns the matching articles, newest first.
+	Query(q Query) ([]ArticleRecord, error)
+	// Update loads the history, applies change and saves the result.
+	// Nothing is saved when change returns an error.
+	Update(change func(*ArticleHistory) error) error
+	// Close releases the store's resources.
+	Close() error
+}
+
+// Query selects articles. Zero fields match every article.
+type Query struct {
+	Topic    string    // Exact topic name
+	Category string    // Exact category name
+	Tag      string    // Tag, compared case-insensitively
+	Since    time.Time // Published at or after
+	Until    time.Time // Published before
+	Status   string    // Review status, e.g. StatusPublished
+	Limit    int       // Maximum number of results; 0 means no limit
+}
+
+// Matches reports whether an article satisfies the query filters.
+func (q Query) Matches(a ArticleRecord) bool {
+	if q.Topic != "" && a.Topic != q.Topic {
+		return false
+	}
+	if q.Category != "" && a.Category != q.Category {
+		return false
+	}
+	if q.Tag != "" && !slices.ContainsFunc(a.Tags, func(tag string) bool { return strings.EqualFold(tag, q.Tag) }) {
+		return false
+	}
+	if !q.Since.IsZero() && a.PublishedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !a.PublishedAt.Before(q.Until) {
+		return false
+	}
+	if q.Status != "" && a.ReviewStatus() != q.Status {
+		return false
+	}
+	return true
+}
+
+// Filter applies a query to articles held in memory.
+func (q Query) Filter(articles []ArticleRecord) []ArticleRecord {
+	var result []ArticleRecord
+	for _, a := range articles {
+		if q.Matches(a) {
+			result = append(result, a)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b ArticleRecord) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result
+}
+
+// Open returns the store configured in the storage section of config.yaml.
+func Open(cfg config.StorageConfig) (Store, error) {
+	switch cfg.Backend {
+	case "", config.StorageJSON:
+		return NewJSONStore(cfg.Path), nil
+	case config.StorageSQLite:
+		return NewSQLiteStore(cfg.Path)
+	case config.StorageS3:
+		client, err := newS3Client(cfg.S3)
+		if err != nil {
+			return nil, fmt.Errorf("invalid s3 storage: %w", err)
+		}
+		return NewS3Store(client, cfg.S3.Prefix+cfg.Path), nil
+	case config.StorageGit:
+		return NewGitStore(cfg.Path, cfg.Git)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// OpenArchive returns where the content of published articles is kept: the
+// bucket of the s3 backend, or the local directory dir for the others.
+func OpenArchive(cfg config.StorageConfig, dir string) (ContentArchive, error) {
+	if cfg.Backend != config.StorageS3 {
+		return NewArchive(dir), nil
+	}
+	client, err := newS3Client(cfg.S3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 storage: %w", err)
+	}
+	return NewS3Archive(client, cfg.S3.Prefix), nil
+}
+
+// appendRecord adds a record to an in-memory history, following the Append
+// contract.
+func appendRecord(history *ArticleHistory, record ArticleRecord) error {
+	if record.ID == "" {
+		record.ID = RecordID(record.Title, record.PublishedAt)
+	}
+	if slices.ContainsFunc(history.Articles, func(a ArticleRecord) bool { return a.ID == record.ID }) {
+		return fmt.Errorf("%w: %s", ErrExists, record.ID)
+	}
+	history.Articles = append(history.Articles, record)
+	return nil
+}
+
+// findRecord returns the record with the given ID in an in-memory history.
+func findRecord(history *ArticleHistory, id string) (ArticleRecord, error) {
+	for _, a := range history.Articles {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return ArticleRecord{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+func TestQueryMatches(t *testing.T) {
+	a := ArticleRecord{Topic: "Go", Category: "Lang", Tags: []string{"Concurrency"}, PublishedAt: contractDay}
+
+	tests := []struct {
+		name string
+		q    Query
+		want bool
+	}{
+		{"empty query", Query{}, true},
+		{"topic", Query{Topic: "Go"}, true},
+		{"other topic", Query{Topic: "go"}, false},
+		{"category", Query{Category: "Lang"}, true},
+		{"tag", Query{Tag: "concurrency"}, true},
+		{"missing tag", Query{Tag: "ai"}, false},
+		{"since is inclusive", Query{Since: contractDay}, true},
+		{"until is exclusive", Query{Until: contractDay}, false},
+		{"after until", Query{Until: contractDay.AddDate(0, 0, 1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.Matches(a); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open(config.StorageConfig{Backend: config.StorageJSON, Path: "history.json"})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if js, ok := store.(*JSONStore); !ok || js.filepath != "history.json" {
+		t.Errorf("Open() = %#v, want a JSON store at history.json", store)
+	}
+	sqlite, err := Open(config.StorageConfig{Backend: config.StorageSQLite, Path: filepath.Join(t.TempDir(), "history.db")})
+	if err != nil {
+		t.Fatalf("Open(sqlite) error = %v", err)
+	}
+	defer func() {
+		_ = sqlite.Close()
+	}()
+	if _, ok := sqlite.(*SQLiteStore); !ok {
+		t.Errorf("Open(sqlite) = %#v, want an SQLite store", sqlite)
+	}
+	s3Store, err := Open(config.StorageConfig{Backend: config.StorageS3, Path: "articles.json", S3: config.S3Config{Bucket: "blog", Prefix: "autoblog/"}})
+	if err != nil {
+		t.Fatalf("Open(s3) error = %v", err)
+	}
+	if s, ok := s3Store.(*S3Store); !ok || s.key != "autoblog/articles.json" {
+		t.Errorf("Open(s3) = %#v, want an S3 store at autoblog/articles.json", s3Store)
+	}
+	if _, err := Open(config.StorageConfig{Backend: "etcd"}); err == nil {
+		t.Error("Open() should reject unknown backends")
+	}
+}
+
+func TestOpenArchive(t *testing.T) {
+	local, err := OpenArchive(config.StorageConfig{Backend: config.StorageJSON}, "archive")
+	if _, ok := local.(*Archive); !ok || err != nil {
+		t.Errorf("OpenArchive(json) = %#v, %v, want the local archive", local, err)
+	}
+	remote, err := OpenArchive(config.StorageConfig{Backend: config.StorageS3, S3: config.S3Config{Bucket: "blog"}}, "archive")
+	if _, ok := remote.(*S3Archive); !ok || err != nil {
+		t.Errorf("OpenArchive(s3) = %#v, %v, want the bucket archive", remote, err)
+	}
+}
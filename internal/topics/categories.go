@@ -0,0 +1,138 @@
+package topics
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// ErrQuotaReached is returned by Select when every category with topics has
+// used up its rolling publishing quota.
+var ErrQuotaReached = errors.New("every topic category has reached its publishing quota")
+
+// CategoryStat summarises a category's topics, quota and publishing history.
+type CategoryStat struct {
+	Name          string // Empty for uncategorized topics
+	Topics        int
+	Weight        int // Effective selection weight
+	Articles      int // Articles published in the category, all time
+	InWindow      int // Articles published within the quota window
+	MaxPosts      int // 0 means unlimited
+	PeriodDays    int
+	LastPublished time.Time
+}
+
+// QuotaReached reports whether the category may not be published again yet.
+func (s CategoryStat) QuotaReached() bool {
+	return s.MaxPosts > 0 && s.InWindow >= s.MaxPosts
+}
+
+// ArticleCategory returns the category of a published article: the category
+// recorded with it, or else the current category of its topic.
+func ArticleCategory(a storage.ArticleRecord, topics []config.TopicConfig) string {
+	if a.Category != "" {
+		return a.Category
+	}
+	for _, t := range topics {
+		if t.Name == a.Topic {
+			return t.Category
+		}
+	}
+	return ""
+}
+
+// CategoryStats summarises each category that has topics or articles, in
+// the order categories first appear in the topic list. Articles whose topic
+// is no longer configured and that carry no category count as uncategorized.
+func CategoryStats(topics []config.TopicConfig, categories []config.CategoryConfig, history *storage.ArticleHistory, now time.Time) []CategoryStat {
+	var order []string
+	stats := make(map[string]*CategoryStat)
+	get := func(name string) *CategoryStat {
+		s, ok := stats[name]
+		if !ok {
+			s = &CategoryStat{Name: name}
+			for _, c := range categories {
+				if c.Name == name {
+					s.Weight, s.MaxPosts, s.PeriodDays = c.Weight, c.MaxPosts, c.PeriodDays
+				}
+			}
+			stats[name] = s
+			order = append(order, name)
+		}
+		return s
+	}
+
+	topicWeights := make(map[string]int)
+	for _, t := range topics {
+		s := get(t.Category)
+		s.Topics++
+		topicWeights[t.Category] += topicWeight(t)
+	}
+
+	if history != nil {
+		for _, a := range history.Articles {
+			s := get(ArticleCategory(a, topics))
+			s.Articles++
+			if s.MaxPosts > 0 && a.PublishedAt.After(now.AddDate(0, 0, -s.PeriodDays)) {
+				s.InWindow++
+			}
+			if a.PublishedAt.After(s.LastPublished) {
+				s.LastPublished = a.PublishedAt
+			}
+		}
+	}
+
+	result := make([]CategoryStat, 0, len(order))
+	for _, name := range order {
+		s := stats[name]
+		if s.Weight == 0 {
+			s.Weight = topicWeights[name]
+		}
+		result = append(result, *s)
+	}
+	return result
+}
+
+// pickCategory chooses a category by weight among those under quota and
+// returns its topics. Topic lists without categories are returned unchanged.
+// Uncategorized topics compete as one more category, weighted like a
+// category without configuration: by the sum of their topic weights, with
+// no quota.
+func pickCategory(rng *rand.Rand, topics []config.TopicConfig, categories []config.CategoryConfig, history *storage.ArticleHistory, now time.Time) ([]config.TopicConfig, error) {
+	categorized := len(categories) > 0
+	for _, t := range topics {
+		if t.Category != "" {
+			categorized = true
+		}
+	}
+	if !categorized {
+		return topics, nil
+	}
+
+	var open []CategoryStat
+	for _, s := range CategoryStats(topics, categories, history, now) {
+		if s.Topics > 0 && !s.QuotaReached() {
+			open = append(open, s)
+		}
+	}
+	if len(open) == 0 {
+		return nil, ErrQuotaReached
+	}
+
+	weights := make([]int, len(open))
+	for i, s := range open {
+		weights[i] = max(s.Weight, 1)
+	}
+	chosen := open[pickIndex(rng, weights)].Name
+
+	var inCategory []config.TopicConfig
+	for _, t := range topics {
+		if t.Category == chosen {
+			inCategory = append(inCategory, t)
+		}
+	}
+	return inCategory, nil
+}
@@ -0,0 +1,110 @@
+package topics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func categorizedTopics() []config.TopicConfig {
+	return []config.TopicConfig{
+		{Name: "Go Generics", Weight: 1, Category: "Go"},
+		{Name: "Go Concurrency", Weight: 2, Category: "Go"},
+		{Name: "RAG Systems", Weight: 1, Category: "AI"},
+		{Name: "Prompt Caching", Weight: 1, Category: "AI"},
+		{Name: "Code Review", Weight: 1},
+	}
+}
+
+func TestCategoryStats(t *testing.T) {
+	categories := []config.CategoryConfig{{Name: "AI", Weight: 5, MaxPosts: 2, PeriodDays: 30}}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "RAG Systems", PublishedAt: daysAgo(40)},
+		{Topic: "Prompt Caching", PublishedAt: daysAgo(10)},
+		{Topic: "Retired Topic", Category: "AI", PublishedAt: daysAgo(3)},
+		{Topic: "Go Generics", PublishedAt: daysAgo(1)},
+		{Topic: "Retired Topic"},
+	}}
+
+	stats := CategoryStats(categorizedTopics(), categories, history, testNow)
+	if len(stats) != 3 {
+		t.Fatalf("CategoryStats() = %d categories, want 3: %+v", len(stats), stats)
+	}
+
+	goStats, ai, none := stats[0], stats[1], stats[2]
+	if goStats.Name != "Go" || goStats.Topics != 2 || goStats.Weight != 3 || goStats.Articles != 1 || goStats.QuotaReached() {
+		t.Errorf("Go stats = %+v", goStats)
+	}
+	if ai.Weight != 5 || ai.Articles != 3 || ai.InWindow != 2 || !ai.QuotaReached() || !ai.LastPublished.Equal(daysAgo(3)) {
+		t.Errorf("AI stats = %+v", ai)
+	}
+	// Uncategorized topics are weighted by the sum of their weights
+	if none.Name != "" || none.Topics != 1 || none.Weight != 1 || none.Articles != 1 {
+		t.Errorf("uncategorized stats = %+v", none)
+	}
+}
+
+func TestSelect_CategoryQuota(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{})
+	s.categories = []config.CategoryConfig{
+		{Name: "AI", MaxPosts: 1, PeriodDays: 30},
+		{Name: "Go", MaxPosts: 1, PeriodDays: 30},
+	}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "RAG Systems", PublishedAt: daysAgo(5)},
+	}}
+
+	for range 100 {
+		got, err := s.Select(categorizedTopics(), history)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		if got == "RAG Systems" || got == "Prompt Caching" {
+			t.Fatalf("Select() = %s, but the AI quota is used up", got)
+		}
+	}
+
+	only := categorizedTopics()[:4]
+	history.Articles = append(history.Articles, storage.ArticleRecord{Topic: "Go Generics", PublishedAt: daysAgo(1)})
+	if _, err := s.Select(only, history); !errors.Is(err, ErrQuotaReached) {
+		t.Errorf("Select() error = %v, want ErrQuotaReached", err)
+	}
+}
+
+func TestSelect_CategoryWeights(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{})
+	s.categories = []config.CategoryConfig{
+		{Name: "Go", Weight: 1},
+		{Name: "AI", Weight: 8},
+	}
+
+	counts := make(map[string]int)
+	for range 1000 {
+		got, err := s.Select(categorizedTopics(), nil)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		counts[got]++
+	}
+
+	// AI has weight 8 against 1 for Go and 1 (summed topic weight) for uncategorized
+	if ai := counts["RAG Systems"] + counts["Prompt Caching"]; ai < 700 {
+		t.Errorf("AI topics selected %d/1000 times, want > 700", ai)
+	}
+	if counts["Code Review"] == 0 {
+		t.Error("uncategorized topics should still be selectable")
+	}
+}
+
+func TestSelect_RoundRobinWithinCategory(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyRoundRobin})
+	topics := categorizedTopics()[:2]
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "Go Generics", PublishedAt: daysAgo(1)},
+	}}
+	if got, _ := s.Select(topics, history); got != "Go Concurrency" {
+		t.Errorf("Select() = %s, want Go Concurrency", got)
+	}
+}
@@ -0,0 +1,67 @@
+package topics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// KeywordStat records how often a topic keyword has been covered.
+type KeywordStat struct {
+	Keyword     string
+	Articles    int
+	LastCovered time.Time
+}
+
+// TopicCoverage lists a topic's keywords with their coverage, in configured order.
+type TopicCoverage struct {
+	Topic    string
+	Keywords []KeywordStat
+}
+
+// Covered returns how many of the topic's keywords appear in at least one article.
+func (c TopicCoverage) Covered() int {
+	n := 0
+	for _, k := range c.Keywords {
+		if k.Articles > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// KeywordCoverage reports, for each topic, which keywords published
+// articles have covered. Keywords are matched case-insensitively.
+func KeywordCoverage(topics []config.TopicConfig, history *storage.ArticleHistory) []TopicCoverage {
+	report := make([]TopicCoverage, 0, len(topics))
+	for _, t := range topics {
+		stats := make([]KeywordStat, len(t.Keywords))
+		index := make(map[string]int, len(t.Keywords))
+		for i, kw := range t.Keywords {
+			stats[i].Keyword = kw
+			index[strings.ToLower(kw)] = i
+		}
+
+		if history != nil {
+			for _, a := range history.Articles {
+				if a.Topic != t.Name {
+					continue
+				}
+				for _, kw := range a.Keywords {
+					i, ok := index[strings.ToLower(kw)]
+					if !ok {
+						continue
+					}
+					stats[i].Articles++
+					if a.PublishedAt.After(stats[i].LastCovered) {
+						stats[i].LastCovered = a.PublishedAt
+					}
+				}
+			}
+		}
+		report = append(report, TopicCoverage{Topic: t.Name, Keywords: stats})
+	}
+	return report
+}
@@ -0,0 +1,40 @@
+package topics
+
+import (
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestKeywordCoverage(t *testing.T) {
+	topics := []config.TopicConfig{
+		{Name: "Go Concurrency", Keywords: []string{"goroutines", "Channels", "context"}},
+		{Name: "RAG Systems", Keywords: []string{"embeddings"}},
+	}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "Go Concurrency", Keywords: []string{"goroutines", "channels"}, PublishedAt: daysAgo(10)},
+		{Topic: "Go Concurrency", Keywords: []string{"channels", "retired keyword"}, PublishedAt: daysAgo(2)},
+		{Topic: "Other", Keywords: []string{"context"}, PublishedAt: daysAgo(1)},
+	}}
+
+	report := KeywordCoverage(topics, history)
+	if len(report) != 2 {
+		t.Fatalf("KeywordCoverage() = %d topics, want 2", len(report))
+	}
+
+	goReport := report[0]
+	if goReport.Covered() != 2 || len(goReport.Keywords) != 3 {
+		t.Errorf("Go coverage = %+v, want 2 of 3 covered", goReport)
+	}
+	channels := goReport.Keywords[1]
+	if channels.Keyword != "Channels" || channels.Articles != 2 || !channels.LastCovered.Equal(daysAgo(2)) {
+		t.Errorf("channels = %+v", channels)
+	}
+	if goReport.Keywords[2].Articles != 0 {
+		t.Error("context is only covered by another topic and should count as uncovered")
+	}
+	if report[1].Covered() != 0 {
+		t.Errorf("RAG coverage = %+v, want nothing covered", report[1])
+	}
+}
@@ -0,0 +1,34 @@
+package topics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+// DiscoverPrompt asks the model to turn trending feed clusters into new
+// topic proposals.
+func DiscoverPrompt(clusters []Cluster, existing []config.TopicConfig, count int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Propose up to %d new article topics for our blog based on what is trending in the feeds we follow.\n\n", count))
+
+	b.WriteString("Recent feed items, grouped by shared keywords (largest groups first):\n")
+	for i, c := range clusters {
+		b.WriteString(fmt.Sprintf("\nGroup %d (keywords: %s):\n", i+1, strings.Join(c.Keywords, ", ")))
+		for _, title := range c.Titles {
+			b.WriteString(fmt.Sprintf("- %s\n", title))
+		}
+	}
+	b.WriteString("\n")
+
+	writeExisting(&b, existing)
+
+	b.WriteString("Each topic should be broad enough for several articles but specific enough to be actionable. ")
+	b.WriteString("Use weight 1 unless a topic is clearly a major trend.\n\n")
+	b.WriteString(proposalFormat)
+	b.WriteString("\n")
+
+	return b.String()
+}
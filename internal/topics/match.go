@@ -0,0 +1,34 @@
+package topics
+
+import (
+	"strings"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+// Match returns the configured topic whose name and keywords best match an
+// article written outside autoblog-ai, along with the keywords it covers.
+// Tags count as well as the text; the topic name scores double. ok is
+// false when no topic matches at all.
+func Match(topics []config.TopicConfig, title string, tags []string, content string) (topic config.TopicConfig, keywords []string, ok bool) {
+	text := strings.ToLower(title + "\n" + strings.Join(tags, "\n") + "\n" + content)
+
+	best := 0
+	for _, t := range topics {
+		score := 0
+		if t.Name != "" && strings.Contains(text, strings.ToLower(t.Name)) {
+			score += 2
+		}
+		var covered []string
+		for _, kw := range t.Keywords {
+			if kw != "" && strings.Contains(text, strings.ToLower(kw)) {
+				covered = append(covered, kw)
+				score++
+			}
+		}
+		if score > best {
+			best, topic, keywords, ok = score, t, covered, true
+		}
+	}
+	return topic, keywords, ok
+}
@@ -0,0 +1,59 @@
+package topics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+)
+
+func TestMatch(t *testing.T) {
+	configured := []config.TopicConfig{
+		{Name: "Go Concurrency", Keywords: []string{"goroutines", "channels", "mutex"}},
+		{Name: "Testing", Keywords: []string{"table tests", "mocks"}},
+	}
+
+	tests := []struct {
+		name         string
+		title        string
+		tags         []string
+		content      string
+		wantTopic    string
+		wantKeywords []string
+		wantOK       bool
+	}{
+		{
+			name:         "keywords in body",
+			title:        "Fan-out patterns",
+			content:      "Goroutines talk over channels.",
+			wantTopic:    "Go Concurrency",
+			wantKeywords: []string{"goroutines", "channels"},
+			wantOK:       true,
+		},
+		{
+			name:      "topic name in tags outweighs one keyword",
+			title:     "Fakes over mocks?",
+			tags:      []string{"Testing"},
+			content:   "A mutex guards the fake.",
+			wantTopic: "Testing", wantKeywords: []string{"mocks"},
+			wantOK: true,
+		},
+		{
+			name:    "no match",
+			title:   "My holiday",
+			content: "Beaches and sunshine.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic, keywords, ok := Match(configured, tt.title, tt.tags, tt.content)
+			if ok != tt.wantOK || topic.Name != tt.wantTopic {
+				t.Fatalf("Match() = %q, %v; want %q, %v", topic.Name, ok, tt.wantTopic, tt.wantOK)
+			}
+			if !reflect.DeepEqual(keywords, tt.wantKeywords) {
+				t.Errorf("keywords = %v, want %v", keywords, tt.wantKeywords)
+			}
+		})
+	}
+}
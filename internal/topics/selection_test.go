@@ -0,0 +1,212 @@
+package topics
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+var testNow = time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+
+func newTestSelector(t *testing.T, cfg config.SelectionConfig) *selector {
+	t.Helper()
+	s, err := NewSelector(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewSelector() error = %v", err)
+	}
+	sel := s.(*selector)
+	sel.now = func() time.Time { return testNow }
+	return sel
+}
+
+func daysAgo(n int) time.Time {
+	return testNow.AddDate(0, 0, -n)
+}
+
+func testTopics() []config.TopicConfig {
+	return []config.TopicConfig{
+		{Name: "A", Weight: 1},
+		{Name: "B", Weight: 1},
+		{Name: "C", Weight: 1},
+	}
+}
+
+func TestNewSelector_UnknownStrategy(t *testing.T) {
+	if _, err := NewSelector(config.SelectionConfig{Strategy: "alphabetical"}, nil); err == nil {
+		t.Error("NewSelector() should reject unknown strategies")
+	}
+}
+
+func TestSelect_NoTopics(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{})
+	if _, err := s.Select(nil, nil); err == nil {
+		t.Error("Select() should error with no topics")
+	}
+}
+
+func TestSelect_Cooldown(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyCooldown, CooldownDays: 21})
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "A", PublishedAt: daysAgo(7)},
+		{Topic: "B", PublishedAt: daysAgo(14)},
+	}}
+
+	for range 50 {
+		got, err := s.Select(testTopics(), history)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		if got != "C" {
+			t.Fatalf("Select() = %s, want C (A and B are cooling down)", got)
+		}
+	}
+
+	// When every topic is cooling down, the one that waited longest wins
+	history.Articles = append(history.Articles, storage.ArticleRecord{Topic: "C", PublishedAt: daysAgo(1)})
+	if got, _ := s.Select(testTopics(), history); got != "B" {
+		t.Errorf("Select() = %s, want B when all topics are cooling down", got)
+	}
+}
+
+func TestSelect_LeastRecent(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyLeastRecent})
+
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "A", PublishedAt: daysAgo(3)},
+		{Topic: "B", PublishedAt: daysAgo(30)},
+		{Topic: "C", PublishedAt: daysAgo(10)},
+		{Topic: "B", PublishedAt: daysAgo(2)},
+	}}
+	if got, _ := s.Select(testTopics(), history); got != "C" {
+		t.Errorf("Select() = %s, want C (oldest last publish)", got)
+	}
+
+	history.Articles = history.Articles[:1]
+	if got, _ := s.Select(testTopics(), history); got != "B" {
+		t.Errorf("Select() = %s, want B (first never-published topic)", got)
+	}
+}
+
+func TestSelect_RoundRobin(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyRoundRobin})
+
+	if got, _ := s.Select(testTopics(), &storage.ArticleHistory{}); got != "A" {
+		t.Errorf("Select() = %s, want A with empty history", got)
+	}
+
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "B", PublishedAt: daysAgo(2)},
+		{Topic: "Removed Topic", PublishedAt: daysAgo(0)},
+		{Topic: "C", PublishedAt: daysAgo(1)},
+	}}
+	if got, _ := s.Select(testTopics(), history); got != "A" {
+		t.Errorf("Select() = %s, want A (wraps after C)", got)
+	}
+}
+
+func TestSelect_Staleness(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyStaleness})
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "A", PublishedAt: daysAgo(0)},
+		{Topic: "B", PublishedAt: daysAgo(0)},
+	}}
+
+	counts := make(map[string]int)
+	for range 1000 {
+		got, _ := s.Select(testTopics(), history)
+		counts[got]++
+	}
+	// C (never published) has weight 90 against 1 for A and B
+	if counts["C"] < 900 {
+		t.Errorf("stale topic C selected %d/1000 times, want > 900", counts["C"])
+	}
+}
+
+func TestSelect_StalenessFutureDates(t *testing.T) {
+	s := newTestSelector(t, config.SelectionConfig{Strategy: StrategyStaleness})
+	// Clock skew or a hand-edited history: every topic "published" ahead of now
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "A", PublishedAt: daysAgo(-30)},
+		{Topic: "B", PublishedAt: daysAgo(-2)},
+		{Topic: "C", PublishedAt: daysAgo(-1)},
+	}}
+
+	counts := make(map[string]int)
+	for range 3000 {
+		got, err := s.Select(testTopics(), history)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		counts[got]++
+	}
+	// Each counts as published today, so the odds stay even
+	for _, name := range []string{"A", "B", "C"} {
+		if counts[name] < 800 {
+			t.Errorf("topic %s selected %d/3000 times, want about 1000", name, counts[name])
+		}
+	}
+}
+
+func TestPickIndex(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name    string
+		weights []int
+		allowed map[int]bool
+	}{
+		{"positive", []int{0, 3, 0}, map[int]bool{1: true}},
+		{"negative ignored", []int{-5, 2, -1}, map[int]bool{1: true}},
+		{"all zero", []int{0, 0}, map[int]bool{0: true, 1: true}},
+		{"all negative", []int{-1, -2, -3}, map[int]bool{0: true, 1: true, 2: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				if got := pickIndex(rng, tt.weights); !tt.allowed[got] {
+					t.Fatalf("pickIndex(%v) = %d", tt.weights, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSelect_Seeded(t *testing.T) {
+	cfg := config.SelectionConfig{Strategy: StrategySeeded, Seed: 42}
+	history := &storage.ArticleHistory{}
+
+	first, _ := newTestSelector(t, cfg).Select(testTopics(), history)
+	for range 10 {
+		got, _ := newTestSelector(t, cfg).Select(testTopics(), history)
+		if got != first {
+			t.Fatalf("seeded Select() = %s, want reproducible %s", got, first)
+		}
+	}
+
+	// Different history lengths advance the sequence
+	seen := map[string]bool{first: true}
+	for i := range 20 {
+		history.Articles = append(history.Articles, storage.ArticleRecord{Topic: "X", PublishedAt: daysAgo(i)})
+		got, _ := newTestSelector(t, cfg).Select(testTopics(), history)
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Error("seeded Select() should vary as history grows")
+	}
+}
+
+func TestLastPublished(t *testing.T) {
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Topic: "A", PublishedAt: daysAgo(5)},
+		{Topic: "A", PublishedAt: daysAgo(1)},
+		{Topic: "A", PublishedAt: daysAgo(3)},
+	}}
+	if got := LastPublished(history)["A"]; !got.Equal(daysAgo(1)) {
+		t.Errorf("LastPublished()[A] = %v, want %v", got, daysAgo(1))
+	}
+	if len(LastPublished(nil)) != 0 {
+		t.Error("LastPublished(nil) should be empty")
+	}
+}
@@ -0,0 +1,55 @@
+package topics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// maxRecentTitles bounds how many published titles are included in the
+// suggestion prompt.
+const maxRecentTitles = 30
+
+// SuggestPrompt asks the model to brainstorm new topics that complement the
+// existing topic list, its keyword clusters and what has been published.
+func SuggestPrompt(existing []config.TopicConfig, clusters []Cluster, history *storage.ArticleHistory, count int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Brainstorm %d new article topics for our technical blog.\n\n", count))
+
+	writeExisting(&b, existing)
+
+	if len(clusters) > 0 {
+		b.WriteString("Our topics group into these keyword clusters (largest first). Suggest topics that extend thin clusters or open adjacent areas:\n")
+		for _, c := range clusters {
+			b.WriteString(fmt.Sprintf("- %s (%d topics)\n", strings.Join(c.Keywords, ", "), len(c.Titles)))
+		}
+		b.WriteString("\n")
+	}
+
+	if history != nil && len(history.Articles) > 0 {
+		articles := make([]storage.ArticleRecord, len(history.Articles))
+		copy(articles, history.Articles)
+		sort.SliceStable(articles, func(i, j int) bool {
+			return articles[i].PublishedAt.After(articles[j].PublishedAt)
+		})
+		if len(articles) > maxRecentTitles {
+			articles = articles[:maxRecentTitles]
+		}
+
+		b.WriteString("Recently published articles (newest first):\n")
+		for _, a := range articles {
+			b.WriteString(fmt.Sprintf("- %s (topic: %s)\n", a.Title, a.Topic))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Give each topic a weight from 1 (occasional) to 3 (core to the blog).\n\n")
+	b.WriteString(proposalFormat)
+	b.WriteString("\n")
+
+	return b.String()
+}
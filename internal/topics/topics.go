@@ -0,0 +1,279 @@
+// Package topics provides topic discovery, deduplication and selection on
+// top of the configured topic list and article history.
+package topics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// EditorSystemPrompt is the system prompt used when asking the model to plan topics.
+const EditorSystemPrompt = "You are the editor of a technical blog. You plan article topics that are specific, " +
+	"timely and valuable to software engineers, and you avoid repeating what the blog already covers."
+
+// similarityThreshold is the keyword overlap above which two topic names
+// are considered duplicates.
+const similarityThreshold = 0.6
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "how": true, "in": true, "into": true, "is": true,
+	"it": true, "its": true, "new": true, "of": true, "on": true, "or": true, "our": true,
+	"the": true, "this": true, "that": true, "to": true, "use": true, "using": true, "vs": true,
+	"we": true, "what": true, "when": true, "why": true, "with": true, "you": true, "your": true,
+}
+
+// Keywords splits text into lower-cased words, dropping stopwords and
+// single-character tokens.
+func Keywords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	var words []string
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || stopwords[f] {
+			continue
+		}
+		words = append(words, f)
+	}
+	return words
+}
+
+// Cluster is a group of titles sharing keywords.
+type Cluster struct {
+	Keywords []string // Most frequent keywords first
+	Titles   []string
+}
+
+// ClusterTitles groups titles that share at least minShared keywords with an
+// existing cluster. Clusters are returned largest first.
+func ClusterTitles(titles []string, minShared int) []Cluster {
+	return cluster(titles, titles, minShared)
+}
+
+// ClusterTopics groups topics by the keywords in their names and keyword
+// lists. Cluster titles are topic names.
+func ClusterTopics(topics []config.TopicConfig, minShared int) []Cluster {
+	labels := make([]string, len(topics))
+	texts := make([]string, len(topics))
+	for i, t := range topics {
+		labels[i] = t.Name
+		texts[i] = t.Name + " " + strings.Join(t.Keywords, " ")
+	}
+	return cluster(labels, texts, minShared)
+}
+
+// cluster groups labels by keyword overlap of the corresponding texts.
+func cluster(labels, texts []string, minShared int) []Cluster {
+	if minShared < 1 {
+		minShared = 1
+	}
+
+	type group struct {
+		counts map[string]int
+		titles []string
+	}
+	var groups []*group
+
+	for i, text := range texts {
+		words := uniqueWords(Keywords(text))
+		if len(words) == 0 {
+			continue
+		}
+
+		var best *group
+		bestShared := 0
+		for _, g := range groups {
+			shared := 0
+			for _, w := range words {
+				if g.counts[w] > 0 {
+					shared++
+				}
+			}
+			if shared > bestShared {
+				best, bestShared = g, shared
+			}
+		}
+
+		if best == nil || bestShared < minShared {
+			best = &group{counts: make(map[string]int)}
+			groups = append(groups, best)
+		}
+		for _, w := range words {
+			best.counts[w]++
+		}
+		best.titles = append(best.titles, labels[i])
+	}
+
+	clusters := make([]Cluster, 0, len(groups))
+	for _, g := range groups {
+		words := make([]string, 0, len(g.counts))
+		for w := range g.counts {
+			words = append(words, w)
+		}
+		sort.Slice(words, func(i, j int) bool {
+			if g.counts[words[i]] != g.counts[words[j]] {
+				return g.counts[words[i]] > g.counts[words[j]]
+			}
+			return words[i] < words[j]
+		})
+		if len(words) > 8 {
+			words = words[:8]
+		}
+		clusters = append(clusters, Cluster{Keywords: words, Titles: g.titles})
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Titles) > len(clusters[j].Titles)
+	})
+	return clusters
+}
+
+func uniqueWords(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	unique := words[:0:0]
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			unique = append(unique, w)
+		}
+	}
+	return unique
+}
+
+// ParseProposals extracts topic proposals from a model response containing a
+// JSON array of objects shaped like config.TopicConfig.
+func ParseProposals(response string) ([]config.TopicConfig, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start == -1 || end == -1 || end < start {
+		return nil, fmt.Errorf("no JSON array found in response")
+	}
+
+	var raw []struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Keywords    []string `json:"keywords"`
+		Weight      int      `json:"weight"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse proposals: %w", err)
+	}
+
+	proposals := make([]config.TopicConfig, 0, len(raw))
+	for _, p := range raw {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			continue
+		}
+		topic := config.TopicConfig{
+			Name:        name,
+			Description: strings.TrimSpace(p.Description),
+			Weight:      p.Weight,
+		}
+		for _, kw := range p.Keywords {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				topic.Keywords = append(topic.Keywords, kw)
+			}
+		}
+		proposals = append(proposals, topic)
+	}
+	return proposals, nil
+}
+
+// Duplicate records a proposal rejected because it matches an existing topic
+// or a published article.
+type Duplicate struct {
+	Topic   config.TopicConfig
+	Matches string
+}
+
+// Dedupe removes proposals that duplicate an existing topic, a previously
+// published article title, or an earlier proposal in the same batch.
+func Dedupe(proposals, existing []config.TopicConfig, history *storage.ArticleHistory) ([]config.TopicConfig, []Duplicate) {
+	var known []string
+	for _, t := range existing {
+		known = append(known, t.Name)
+	}
+	if history != nil {
+		for _, a := range history.Articles {
+			known = append(known, a.Title)
+		}
+	}
+
+	var fresh []config.TopicConfig
+	var dupes []Duplicate
+	for _, p := range proposals {
+		if match, ok := findSimilar(p.Name, known); ok {
+			dupes = append(dupes, Duplicate{Topic: p, Matches: match})
+			continue
+		}
+		fresh = append(fresh, p)
+		known = append(known, p.Name)
+	}
+	return fresh, dupes
+}
+
+func findSimilar(name string, candidates []string) (string, bool) {
+	for _, c := range candidates {
+		if Similarity(name, c) >= similarityThreshold {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// Similarity returns the Jaccard similarity of the keyword sets of a and b.
+func Similarity(a, b string) float64 {
+	if strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b)) {
+		return 1
+	}
+
+	wa := uniqueWords(Keywords(a))
+	wb := uniqueWords(Keywords(b))
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(wa))
+	for _, w := range wa {
+		set[w] = true
+	}
+	shared := 0
+	for _, w := range wb {
+		if set[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
+}
+
+// proposalFormat describes the JSON shape expected from the model.
+const proposalFormat = `Return only a JSON array in this exact format:
+[
+  {
+    "name": "Specific Topic Name",
+    "description": "What an article on this topic should focus on",
+    "keywords": ["keyword1", "keyword2", "keyword3"],
+    "weight": 1
+  }
+]`
+
+// writeExisting lists existing topic names in a prompt.
+func writeExisting(b *strings.Builder, existing []config.TopicConfig) {
+	if len(existing) == 0 {
+		return
+	}
+	b.WriteString("Topics we already cover (do not propose these or close variants):\n")
+	for _, t := range existing {
+		b.WriteString(fmt.Sprintf("- %s\n", t.Name))
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,152 @@
+package topics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func TestKeywords(t *testing.T) {
+	got := Keywords("How to Use Go Generics: A Practical Guide to C++ and C# Interop")
+	want := []string{"go", "generics", "practical", "guide", "c++", "c#", "interop"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Keywords() = %v, want %v", got, want)
+	}
+}
+
+func TestClusterTitles(t *testing.T) {
+	titles := []string{
+		"Structured logging in Go with slog",
+		"Go slog handlers for structured logging",
+		"Evaluating RAG pipelines",
+		"RAG pipelines with vector databases",
+		"Better RAG pipelines through reranking",
+		"Kubernetes 1.35 release notes",
+	}
+
+	clusters := ClusterTitles(titles, 2)
+	if len(clusters) != 3 {
+		t.Fatalf("ClusterTitles() = %d clusters, want 3: %+v", len(clusters), clusters)
+	}
+	if len(clusters[0].Titles) != 3 || !strings.Contains(clusters[0].Titles[0], "RAG") {
+		t.Errorf("largest cluster should be the RAG group, got %+v", clusters[0])
+	}
+	if clusters[0].Keywords[0] != "pipelines" && clusters[0].Keywords[0] != "rag" {
+		t.Errorf("most frequent keyword = %q, want rag or pipelines", clusters[0].Keywords[0])
+	}
+	if len(clusters[2].Titles) != 1 {
+		t.Errorf("smallest cluster should be a singleton, got %+v", clusters[2])
+	}
+}
+
+func TestParseProposals(t *testing.T) {
+	response := `Here are some ideas:
+[
+  {"name": "Structured Logging with slog", "description": "Handlers and attributes", "keywords": ["slog", " logging "], "weight": 2},
+  {"name": "  ", "description": "missing name"}
+]`
+
+	proposals, err := ParseProposals(response)
+	if err != nil {
+		t.Fatalf("ParseProposals() error = %v", err)
+	}
+	if len(proposals) != 1 {
+		t.Fatalf("ParseProposals() = %d proposals, want 1", len(proposals))
+	}
+	p := proposals[0]
+	if p.Name != "Structured Logging with slog" || p.Weight != 2 || len(p.Keywords) != 2 || p.Keywords[1] != "logging" {
+		t.Errorf("proposal = %+v", p)
+	}
+
+	if _, err := ParseProposals("no json here"); err == nil {
+		t.Error("ParseProposals() should error without a JSON array")
+	}
+	if _, err := ParseProposals("[{broken}]"); err == nil {
+		t.Error("ParseProposals() should error on invalid JSON")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	existing := []config.TopicConfig{{Name: "Advanced Go Concurrency Patterns"}}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Title: "Building RAG Systems from Scratch"},
+	}}
+	proposals := []config.TopicConfig{
+		{Name: "Go Concurrency Patterns"},
+		{Name: "RAG Systems from Scratch"},
+		{Name: "Structured Logging with slog"},
+		{Name: "structured logging with slog"},
+	}
+
+	fresh, dupes := Dedupe(proposals, existing, history)
+	if len(fresh) != 1 || fresh[0].Name != "Structured Logging with slog" {
+		t.Errorf("fresh = %+v, want only the slog topic", fresh)
+	}
+	if len(dupes) != 3 {
+		t.Fatalf("dupes = %d, want 3", len(dupes))
+	}
+	if dupes[0].Matches != "Advanced Go Concurrency Patterns" || dupes[1].Matches != "Building RAG Systems from Scratch" {
+		t.Errorf("dupes = %+v", dupes)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("Go Generics", "go generics"); got != 1 {
+		t.Errorf("Similarity(equal) = %v, want 1", got)
+	}
+	if got := Similarity("Go Generics", "Rust Ownership"); got != 0 {
+		t.Errorf("Similarity(disjoint) = %v, want 0", got)
+	}
+	if got := Similarity("the", "a"); got != 0 {
+		t.Errorf("Similarity(stopwords) = %v, want 0", got)
+	}
+}
+
+func TestDiscoverPrompt(t *testing.T) {
+	clusters := []Cluster{{Keywords: []string{"rag", "pipelines"}, Titles: []string{"Evaluating RAG pipelines"}}}
+	existing := []config.TopicConfig{{Name: "Implementing RAG Systems"}}
+
+	prompt := DiscoverPrompt(clusters, existing, 3)
+	for _, want := range []string{"up to 3 new article topics", "keywords: rag, pipelines", "- Evaluating RAG pipelines", "- Implementing RAG Systems", `"keywords"`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("DiscoverPrompt() missing %q", want)
+		}
+	}
+}
+
+func TestClusterTopics(t *testing.T) {
+	clusters := ClusterTopics([]config.TopicConfig{
+		{Name: "Go Concurrency", Keywords: []string{"goroutines", "channels"}},
+		{Name: "Go Generics", Keywords: []string{"types"}},
+		{Name: "RAG Systems", Keywords: []string{"embeddings"}},
+	}, 1)
+
+	if len(clusters) != 2 {
+		t.Fatalf("ClusterTopics() = %d clusters, want 2: %+v", len(clusters), clusters)
+	}
+	if len(clusters[0].Titles) != 2 || clusters[0].Keywords[0] != "go" {
+		t.Errorf("first cluster = %+v, want the two Go topics", clusters[0])
+	}
+}
+
+func TestSuggestPrompt(t *testing.T) {
+	existing := []config.TopicConfig{{Name: "Go Generics"}}
+	clusters := []Cluster{{Keywords: []string{"go", "generics"}, Titles: []string{"Go Generics"}}}
+	history := &storage.ArticleHistory{Articles: []storage.ArticleRecord{
+		{Title: "Older Post", Topic: "Go Generics", PublishedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "Newer Post", Topic: "Go Generics", PublishedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	prompt := SuggestPrompt(existing, clusters, history, 4)
+	for _, want := range []string{"Brainstorm 4 new article topics", "- Go Generics", "- go, generics (1 topics)", `"weight": 1`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("SuggestPrompt() missing %q", want)
+		}
+	}
+	if strings.Index(prompt, "Newer Post") > strings.Index(prompt, "Older Post") {
+		t.Error("SuggestPrompt() should list published articles newest first")
+	}
+}
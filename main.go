@@ -3,16 +3,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/calendar"
+	"github.com/yourusername/autoblog-ai/internal/changelog"
+	"github.com/yourusername/autoblog-ai/internal/codemap"
 	"github.com/yourusername/autoblog-ai/internal/config"
 	"github.com/yourusername/autoblog-ai/internal/medium"
+	"github.com/yourusername/autoblog-ai/internal/outbox"
+	"github.com/yourusername/autoblog-ai/internal/publish"
 	"github.com/yourusername/autoblog-ai/internal/storage"
+	"github.com/yourusername/autoblog-ai/internal/topics"
 )
 
 func main() {
@@ -21,10 +30,29 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
+	// Dispatch subcommands
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				log.Fatalf("%s: %v", os.Args[1], err)
+			}
+			return
+		}
+	}
+
 	// Parse command line flags
 	configPath := flag.String("config", "config.yaml", "Path to configuration file")
 	dryRun := flag.Bool("dry-run", false, "Generate article but don't publish")
+	review := flag.Bool("review", false, "Store the article as a pending draft for review instead of publishing (default from review.required)")
 	topicFlag := flag.String("topic", "", "Specific topic to write about (overrides random selection)")
+	var sources stringListFlag
+	flag.Var(&sources, "source", "PDF, Markdown or text file to use as source material (repeatable)")
+	fromSource := flag.String("from-source", "", "Path to a local Go module to explain in the article")
+	sourceTokens := flag.Int("source-tokens", 8000, "Token budget for the code map built by --from-source")
+	fromChangelog := flag.String("from-changelog", "", "Path to a Keep a Changelog file to announce a release from")
+	releaseVersion := flag.String("release", "", "Version to announce with --from-changelog (default: latest release)")
+	gitRepo := flag.String("git-repo", ".", "Local git repository to read commits from with --git-range")
+	gitRange := flag.String("git-range", "", "Commit range to include in release notes, e.g. v0.2.0..v0.2.1")
 	flag.Parse()
 
 	// Load configuration
@@ -39,15 +67,32 @@ func main() {
 		log.Fatal("ANTHROPIC_API_KEY is required (set in config.yaml or environment variable)")
 	}
 
+	if cfg.Review.Required {
+		*review = true
+	}
+
 	mediumToken := cfg.GetMediumToken()
-	if mediumToken == "" && !*dryRun {
+	if mediumToken == "" && !*dryRun && !*review {
 		log.Fatal("MEDIUM_TOKEN is required (set in config.yaml or environment variable, or use --dry-run)")
 	}
 
 	// Initialize services
 	generator := article.NewGenerator(anthropicKey, cfg)
 	publisher := medium.NewPublisher(mediumToken)
-	store := storage.NewJSONStore("articles.json")
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Invalid storage configuration: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	// Settle publishes an earlier run left in flight before choosing a topic
+	if !*dryRun && !*review {
+		if err := publish.Reconcile(context.Background(), store, publisher); err != nil {
+			log.Printf("Warning: Could not reconcile interrupted publishes: %v", err)
+		}
+	}
 
 	// Load article history
 	history, err := store.Load()
@@ -55,28 +100,124 @@ func main() {
 		log.Printf("Warning: Could not load article history: %v", err)
 		history = &storage.ArticleHistory{Articles: []storage.ArticleRecord{}}
 	}
+	// Drafts awaiting review count towards cooldowns and duplicate checks;
+	// rejected ones do not
+	history = history.WithoutRejected()
+
+	// Build code map for source-code articles
+	var codeMap *codemap.Map
+	if *fromSource != "" {
+		codeMap, err = codemap.Build(*fromSource)
+		if err != nil {
+			log.Fatalf("Failed to read Go source: %v", err)
+		}
+		log.Printf("Built code map for %s (%d packages)", codeMap.Module, len(codeMap.Packages))
+	}
+
+	// Parse release notes for release announcements
+	var release *changelog.Release
+	if *fromChangelog != "" {
+		releases, err := changelog.ParseFile(*fromChangelog)
+		if err != nil {
+			log.Fatalf("Failed to parse changelog: %v", err)
+		}
+		release, err = changelog.Find(releases, *releaseVersion)
+		if err != nil {
+			log.Fatalf("Failed to find release: %v", err)
+		}
+		if *gitRange != "" {
+			release.Commits, err = changelog.GitLog(context.Background(), *gitRepo, *gitRange)
+			if err != nil {
+				log.Fatalf("Failed to read git history: %v", err)
+			}
+		}
+		log.Printf("Announcing release %s (%d sections, %d commits)", release.Version, len(release.Sections), len(release.Commits))
+	}
+
+	// Consult the editorial calendar unless the topic was chosen explicitly
+	var plan *calendar.Entry
+	if cfg.CalendarFile != "" && *topicFlag == "" && release == nil && codeMap == nil {
+		cal, err := calendar.Load(cfg.CalendarFile)
+		if err != nil {
+			log.Fatalf("Failed to load calendar: %v", err)
+		}
+		today := time.Now()
+		if blackout, ok := cal.BlackoutOn(today); ok {
+			log.Printf("Skipping: %s is a blackout date (%s)", today.Format("2006-01-02"), blackout.Reason)
+			return
+		}
+		if entry, ok := cal.PlanFor(today, history); ok {
+			plan = &entry
+			log.Printf("Editorial calendar for %s: topic=%q format=%q theme=%q", entry.When, entry.Topic, entry.Format, entry.Theme)
+		}
+	}
 
 	// Select topic
 	var topic string
-	if *topicFlag != "" {
+	switch {
+	case *topicFlag != "":
 		topic = *topicFlag
-	} else {
-		topic = cfg.SelectRandomTopic()
+	case release != nil:
+		topic = fmt.Sprintf("Release v%s", release.Version)
+	case codeMap != nil:
+		topic = fmt.Sprintf("How %s works", codeMap.Module)
+	case plan != nil && plan.Topic != "":
+		topic = plan.Topic
+	default:
+		selector, err := topics.NewSelector(cfg.Selection, cfg.Categories)
+		if err != nil {
+			log.Fatalf("Invalid topic selection: %v", err)
+		}
+		topic, err = selector.Select(cfg.EnabledTopics(), history)
+		if errors.Is(err, topics.ErrQuotaReached) {
+			log.Printf("Skipping: %v", err)
+			return
+		}
+		if err != nil {
+			log.Fatalf("Failed to select topic: %v", err)
+		}
 	}
 
 	log.Printf("Generating article about: %s", topic)
 
+	// Load source documents
+	var opts []article.GenerateOption
+	for _, path := range sources {
+		doc, err := article.LoadDocument(path)
+		if err != nil {
+			log.Fatalf("Failed to load source: %v", err)
+		}
+		opts = append(opts, article.WithDocuments(doc))
+	}
+	if codeMap != nil {
+		opts = append(opts, article.WithCodeMap(codeMap.Render(*sourceTokens)))
+	}
+	if plan != nil && (plan.Format != "" || plan.Theme != "") {
+		opts = append(opts, article.WithEditorial(plan.Format, plan.Theme))
+	}
+	if release != nil {
+		opts = append(opts,
+			article.WithReleaseNotes(release.Notes()),
+			article.WithPromptTemplate(cfg.GetReleasePromptPath()))
+	}
+
 	// Generate article
-	generatedArticle, err := generator.Generate(context.Background(), topic, history)
+	generatedArticle, err := generateDistinct(context.Background(), generator, cfg, topic, history, opts)
 	if err != nil {
 		log.Fatalf("Failed to generate article: %v", err)
 	}
 
 	log.Printf("Generated article: %s", generatedArticle.Title)
-	log.Printf("Word count: %d", len(generatedArticle.Content)/5) // Rough estimate
+	log.Printf("Word count: %d", len(strings.Fields(generatedArticle.Content)))
 
 	// Save article locally
-	if err := saveArticleLocally(generatedArticle); err != nil {
+	meta := article.NewFrontMatter(generatedArticle, topic)
+	meta.Status = "draft"
+	if *review {
+		meta.Status = storage.StatusPending
+	}
+	savedPath, err := saveArticleLocally(meta, generatedArticle.Content)
+	if err != nil {
 		log.Printf("Warning: Could not save article locally: %v", err)
 	}
 
@@ -90,40 +231,117 @@ func main() {
 		return
 	}
 
+	if *review {
+		record := newRecord(cfg, topic, generatedArticle)
+		record.Status = storage.StatusPending
+		if err := saveDraft(cfg, store, &record, generatedArticle.Content); err != nil {
+			log.Fatalf("Failed to save draft: %v", err)
+		}
+		log.Printf("Saved draft %s for review (see autoblog-ai review show %s)", record.ID, record.ID)
+		return
+	}
+
 	// Publish to Medium
 	log.Println("Publishing to Medium...")
-	publishedURL, err := publisher.Publish(context.Background(), generatedArticle)
+	record := newRecord(cfg, topic, generatedArticle)
+	// The saved copy is committed with the history under the git store, so
+	// mark it published before the record is saved
+	onLive := publish.OnLive(func(published storage.ArticleRecord) {
+		if savedPath == "" {
+			return
+		}
+		meta.Status, meta.CanonicalURL = storage.StatusPublished, published.URL
+		if err := updateArticleLocally(savedPath, meta, generatedArticle.Content); err != nil {
+			log.Printf("Warning: Could not update %s: %v", savedPath, err)
+		}
+	})
+	record, err = publishArticle(context.Background(), cfg, store, publisher, record, generatedArticle.Content, onLive)
 	if err != nil {
-		log.Fatalf("Failed to publish article: %v", err)
+		if errors.Is(err, publish.ErrNotRecorded) {
+			log.Fatalf("%v", err)
+		}
+		// Keep the article so that retrying does not generate a new one
+		path, saveErr := outbox.New(outboxDir).Add(outbox.Entry{
+			ID:       record.ID,
+			Topic:    topic,
+			Article:  generatedArticle,
+			Error:    err.Error(),
+			FailedAt: time.Now(),
+			Attempts: 1,
+		})
+		if saveErr != nil {
+			log.Fatalf("Failed to publish article: %v (could not save it to the outbox: %v)", err, saveErr)
+		}
+		log.Fatalf("Failed to publish article: %v (saved to %s, retry with: autoblog-ai outbox flush)", err, path)
 	}
 
-	log.Printf("Successfully published: %s", publishedURL)
+	log.Println("Done!")
+}
 
-	// Update history
-	history.Articles = append(history.Articles, storage.ArticleRecord{
-		Title:       generatedArticle.Title,
+// newRecord returns the history record of a generated article.
+func newRecord(cfg *config.Config, topic string, generated *article.Article) storage.ArticleRecord {
+	var category string
+	if details := cfg.GetTopicDetails(topic); details != nil {
+		category = details.Category
+	}
+	record := storage.ArticleRecord{
+		ID:          storage.RecordID(generated.Title, generated.PublishedAt),
+		Title:       generated.Title,
 		Topic:       topic,
-		PublishedAt: generatedArticle.PublishedAt,
-		URL:         publishedURL,
-		Tags:        generatedArticle.Tags,
-	})
-
-	if err := store.Save(history); err != nil {
-		log.Printf("Warning: Could not save article history: %v", err)
+		Category:    category,
+		PublishedAt: generated.PublishedAt,
+		Tags:        generated.Tags,
+		Keywords:    generated.Keywords,
+		References:  generated.References(),
+		Fingerprint: article.Fingerprint(generated.Title, generated.Content),
+		Summary:     article.Summarize(generated.Content),
+		Model:       generated.Model,
+		PromptHash:  generated.PromptHash,
+		Format:      generated.Format,
 	}
+	if generated.Usage != (storage.Usage{}) {
+		usage := generated.Usage
+		record.Usage = &usage
+	}
+	return record
+}
 
-	log.Println("Done!")
+// saveDraft archives a draft's content and appends its record to the
+// history. Drafts are published later from the archive, so failing to
+// archive one is an error.
+func saveDraft(cfg *config.Config, store storage.Store, record *storage.ArticleRecord, content string) error {
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return err
+	}
+	if err := archive.Put(record, content); err != nil {
+		return fmt.Errorf("failed to archive content: %w", err)
+	}
+	return store.Append(*record)
 }
 
-func saveArticleLocally(article *article.Article) error {
+// saveArticleLocally saves an article with its front matter to a new file
+// in generated/ and returns the file's path.
+func saveArticleLocally(meta article.FrontMatter, content string) (string, error) {
 	// #nosec G301 -- 0755 is appropriate for output directory
-	if err := os.MkdirAll("generated", 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	if err := os.MkdirAll(generatedDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
 	}
-	filename := fmt.Sprintf("generated/%s.md", sanitizeFilename(article.Title))
-	return os.WriteFile(filename, []byte(article.Content), 0600)
+	return article.SaveMarkdown(generatedDir, meta, content)
 }
 
+// updateArticleLocally rewrites a saved article, such as with its URL once
+// it is published.
+func updateArticleLocally(path string, meta article.FrontMatter, content string) error {
+	data, err := article.FormatMarkdown(meta, content)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// sanitizeFilename returns the name, without extension, under which
+// articles were saved in generated/ before they had front matter.
 func sanitizeFilename(s string) string {
 	// Simple sanitization - replace spaces and special chars
 	result := ""
@@ -143,3 +361,15 @@ func minInt(a, b int) int {
 	}
 	return b
 }
+
+// stringListFlag collects the values of a repeatable string flag.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/outbox"
+	"github.com/yourusername/autoblog-ai/internal/publish"
+)
+
+func runOutbox(args []string) error {
+	return subcommand("outbox", args, map[string]func([]string) error{
+		"list":  runOutboxList,
+		"flush": runOutboxFlush,
+	})
+}
+
+// runOutboxList lists the articles waiting in the outbox.
+func runOutboxList(args []string) error {
+	fs, _ := newFlagSet("outbox list")
+	_ = fs.Parse(args)
+
+	entries, err := outbox.New(outboxDir).List()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tFAILED\tATTEMPTS\tTOPIC\tTITLE\tERROR")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", e.ID, e.FailedAt.Format("2006-01-02 15:04"), e.Attempts, orDash(e.Topic), e.Article.Title, e.Error)
+	}
+	return w.Flush()
+}
+
+// runOutboxFlush publishes the articles in the outbox, oldest first, and
+// removes the ones that succeed. Failures stay for the next flush.
+func runOutboxFlush(args []string) error {
+	fs, configPath := newFlagSet("outbox flush")
+	_ = fs.Parse(args)
+
+	box := outbox.New(outboxDir)
+	entries, err := box.List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		log.Println("Outbox is empty")
+		return nil
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	publisher, err := newPublisher(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := publish.Reconcile(ctx, store, publisher); err != nil {
+		return fmt.Errorf("failed to reconcile interrupted publishes: %w", err)
+	}
+
+	failed := 0
+	for _, e := range entries {
+		log.Printf("Publishing %s from the outbox: %s", e.ID, e.Article.Title)
+		// Keep the ID, so retries of the article are recognised
+		record := newRecord(cfg, e.Topic, e.Article)
+		record.ID = e.ID
+		_, err := publishArticle(ctx, cfg, store, publisher, record, e.Article.Content)
+		switch {
+		case errors.Is(err, publish.ErrAlreadyPublished):
+			log.Printf("%s is already published, removing it from the outbox: %v", e.ID, err)
+		case errors.Is(err, publish.ErrNotRecorded):
+			// Live: the next run records it, retrying would publish it twice
+			log.Printf("%v", err)
+			failed++
+		case err != nil:
+			log.Printf("%v", err)
+			e.Error, e.FailedAt = err.Error(), time.Now()
+			e.Attempts++
+			if _, err := box.Add(e); err != nil {
+				log.Printf("Warning: Could not update outbox entry %s: %v", e.ID, err)
+			}
+			failed++
+			continue
+		}
+		if err := box.Remove(e.ID); err != nil {
+			return fmt.Errorf("could not remove %s from the outbox: %w", e.ID, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d articles could not be published", failed, len(entries))
+	}
+	return nil
+}
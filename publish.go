@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/medium"
+	"github.com/yourusername/autoblog-ai/internal/publish"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+// runPublish publishes articles that were generated earlier: drafts that
+// passed review, or a Markdown file such as one saved in generated/.
+func runPublish(args []string) error {
+	fs, configPath := newFlagSet("publish")
+	approved := fs.Bool("approved", false, "Publish the oldest approved drafts")
+	limit := fs.Int("limit", 1, "Maximum number of drafts to publish with --approved")
+	file := fs.String("file", "", "Publish this Markdown file (with optional front matter)")
+	topic := fs.String("topic", "", "Topic to record for --file (default from the front matter)")
+	_ = fs.Parse(args)
+	if *approved == (*file != "") {
+		return fmt.Errorf("usage: autoblog-ai publish --approved [--limit N] | --file <article.md> [--topic NAME]")
+	}
+	if *limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	var fromFile *article.Article
+	if *file != "" {
+		a, meta, err := article.LoadMarkdown(*file)
+		if err != nil {
+			return err
+		}
+		if meta.Status == storage.StatusPublished {
+			return fmt.Errorf("%s is already published at %s (remove its status from the front matter to publish it again)", *file, orDash(meta.CanonicalURL))
+		}
+		if *topic == "" {
+			*topic = meta.Topic
+		}
+		fromFile = a
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	publisher, err := newPublisher(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := publish.Reconcile(ctx, store, publisher); err != nil {
+		return fmt.Errorf("failed to reconcile interrupted publishes: %w", err)
+	}
+
+	if fromFile != nil {
+		_, err = publishArticle(ctx, cfg, store, publisher, newRecord(cfg, *topic, fromFile), fromFile.Content)
+		return err
+	}
+
+	drafts, err := reviewQueue(store, storage.StatusApproved)
+	if err != nil {
+		return err
+	}
+	if len(drafts) == 0 {
+		log.Println("No approved drafts to publish")
+		return nil
+	}
+	if len(drafts) > *limit {
+		drafts = drafts[:*limit]
+	}
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return err
+	}
+	for _, draft := range drafts {
+		content, err := archive.Get(draft)
+		if err != nil {
+			return fmt.Errorf("failed to read the content of %s: %w", draft.ID, err)
+		}
+		log.Printf("Publishing draft %s: %s", draft.ID, draft.Title)
+		if _, err := publishArticle(ctx, cfg, store, publisher, draft, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newPublisher returns the Medium publisher, which needs MEDIUM_TOKEN.
+func newPublisher(cfg *config.Config) (medium.Publisher, error) {
+	token := cfg.GetMediumToken()
+	if token == "" {
+		return nil, fmt.Errorf("MEDIUM_TOKEN is required (set in config.yaml or environment variable)")
+	}
+	return medium.NewPublisher(token), nil
+}
+
+// publishArticle publishes an article exactly once and records it in the
+// history, see publish.Publish. The record ID identifies the article across
+// retries.
+func publishArticle(ctx context.Context, cfg *config.Config, store storage.Store, publisher medium.Publisher, record storage.ArticleRecord, content string, opts ...publish.Option) (storage.ArticleRecord, error) {
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return record, err
+	}
+	record, err = publish.Publish(ctx, store, archive, publisher, record, content, opts...)
+	if err != nil {
+		return record, fmt.Errorf("failed to publish %s: %w", record.ID, err)
+	}
+	log.Printf("Successfully published: %s", record.URL)
+	return record, nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/storage"
+)
+
+func runReview(args []string) error {
+	return subcommand("review", args, map[string]func([]string) error{
+		"list":    runReviewList,
+		"show":    runReviewShow,
+		"approve": runReviewApprove,
+		"reject":  runReviewReject,
+		"edit":    runReviewEdit,
+	})
+}
+
+// reviewQueue returns the drafts with one of the given statuses, oldest
+// first.
+func reviewQueue(store storage.Store, statuses ...string) ([]storage.ArticleRecord, error) {
+	var drafts []storage.ArticleRecord
+	for _, status := range statuses {
+		matching, err := store.Query(storage.Query{Status: status})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query drafts: %w", err)
+		}
+		drafts = append(drafts, matching...)
+	}
+	slices.SortStableFunc(drafts, func(a, b storage.ArticleRecord) int {
+		return a.PublishedAt.Compare(b.PublishedAt)
+	})
+	return drafts, nil
+}
+
+// updateDraft applies change to the draft with the given ID and returns
+// the updated record. Published articles are refused.
+func updateDraft(store storage.Store, id string, change func(*storage.ArticleRecord) error) (storage.ArticleRecord, error) {
+	var updated storage.ArticleRecord
+	err := store.Update(func(history *storage.ArticleHistory) error {
+		for i := range history.Articles {
+			a := &history.Articles[i]
+			if a.ID != id {
+				continue
+			}
+			if a.Published() {
+				return fmt.Errorf("article %s is already published", id)
+			}
+			if a.Status == storage.StatusPublishing {
+				return fmt.Errorf("article %s is being published", id)
+			}
+			if err := change(a); err != nil {
+				return err
+			}
+			updated = *a
+			return nil
+		}
+		return fmt.Errorf("no draft with ID %q (see autoblog-ai review list)", id)
+	})
+	return updated, err
+}
+
+// runReviewList lists drafts in review, oldest first.
+func runReviewList(args []string) error {
+	fs, configPath := newFlagSet("review list")
+	status := fs.String("status", "", "Only drafts with this status: pending, approved, rejected or publishing (default pending and approved)")
+	format := fs.String("format", formatTable, "Output format: table or json")
+	_ = fs.Parse(args)
+
+	statuses := []string{storage.StatusPending, storage.StatusApproved}
+	switch *status {
+	case "":
+	case storage.StatusPending, storage.StatusApproved, storage.StatusRejected, storage.StatusPublishing:
+		statuses = []string{*status}
+	default:
+		return fmt.Errorf("unknown status %q (expected pending, approved, rejected or publishing)", *status)
+	}
+	if *format != formatTable && *format != formatJSON {
+		return fmt.Errorf("unknown format %q (expected table or json)", *format)
+	}
+
+	_, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	drafts, err := reviewQueue(store, statuses...)
+	if err != nil {
+		return err
+	}
+
+	if *format == formatJSON {
+		return writeJSON(os.Stdout, nonNil(drafts))
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tGENERATED\tSTATUS\tTOPIC\tWORDS\tTITLE")
+	for _, a := range drafts {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.ID, a.PublishedAt.Format("2006-01-02 15:04"), a.Status, orDash(a.Topic), countOrDash(a.WordCount), a.Title)
+	}
+	return w.Flush()
+}
+
+// runReviewShow prints a draft's metadata followed by its content.
+func runReviewShow(args []string) error {
+	fs, configPath := newFlagSet("review show")
+	positional, err := parsePositional(fs, args, "draft id")
+	if err != nil {
+		return err
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	a, err := store.Get(positional[0])
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("no draft with ID %q (see autoblog-ai review list)", positional[0])
+	}
+	if err != nil {
+		return err
+	}
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return err
+	}
+	content, err := archive.Get(a)
+	if err != nil {
+		return fmt.Errorf("failed to read the content of %s: %w", a.ID, err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	field := func(name, value string) {
+		if value != "" {
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", name, value)
+		}
+	}
+	field("ID", a.ID)
+	field("Status", a.ReviewStatus())
+	field("Review note", a.ReviewNote)
+	field("Title", a.Title)
+	field("Topic", a.Topic)
+	field("Generated", a.PublishedAt.Format(time.RFC3339))
+	field("Tags", strings.Join(a.Tags, ", "))
+	field("Words", countOrDash(a.WordCount))
+	field("Model", a.Model)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Printf("\n%s\n", content)
+	return err
+}
+
+// runReviewApprove queues a draft for "publish --approved".
+func runReviewApprove(args []string) error {
+	fs, configPath := newFlagSet("review approve")
+	positional, err := parsePositional(fs, args, "draft id")
+	if err != nil {
+		return err
+	}
+
+	_, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	a, err := updateDraft(store, positional[0], func(a *storage.ArticleRecord) error {
+		a.Status, a.ReviewNote = storage.StatusApproved, ""
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Approved %s: %s", a.ID, a.Title)
+	return nil
+}
+
+// runReviewReject keeps a draft from being published. Rejected drafts stay
+// in the history but no longer count towards topic cooldowns.
+func runReviewReject(args []string) error {
+	fs, configPath := newFlagSet("review reject")
+	reason := fs.String("reason", "", "Why the draft was rejected")
+	positional, err := parsePositional(fs, args, "draft id")
+	if err != nil {
+		return err
+	}
+
+	_, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	a, err := updateDraft(store, positional[0], func(a *storage.ArticleRecord) error {
+		a.Status, a.ReviewNote = storage.StatusRejected, *reason
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Rejected %s: %s", a.ID, a.Title)
+	return nil
+}
+
+// runReviewEdit changes a draft's title, tags or content. Without flags the
+// content is opened in $VISUAL or $EDITOR.
+func runReviewEdit(args []string) error {
+	fs, configPath := newFlagSet("review edit")
+	title := fs.String("title", "", "New title")
+	tags := fs.String("tags", "", "New comma-separated tags")
+	file := fs.String("file", "", "Replace the content with this Markdown file")
+	positional, err := parsePositional(fs, args, "draft id")
+	if err != nil {
+		return err
+	}
+
+	cfg, store, err := openHistory(*configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	draft, err := store.Get(positional[0])
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("no draft with ID %q (see autoblog-ai review list)", positional[0])
+	}
+	if err != nil {
+		return err
+	}
+	if draft.Published() {
+		return fmt.Errorf("article %s is already published", draft.ID)
+	}
+	if draft.Status == storage.StatusPublishing {
+		return fmt.Errorf("article %s is being published", draft.ID)
+	}
+	archive, err := storage.OpenArchive(cfg.Storage, archiveDir)
+	if err != nil {
+		return err
+	}
+	content, err := archive.Get(draft)
+	if err != nil {
+		return fmt.Errorf("failed to read the content of %s: %w", draft.ID, err)
+	}
+
+	edited := content
+	switch {
+	case *file != "":
+		// #nosec G304 -- file path is provided by the user
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		edited = string(data)
+	case *title == "" && *tags == "":
+		if edited, err = editInEditor(content); err != nil {
+			return err
+		}
+	}
+
+	if *title != "" {
+		draft.Title = *title
+	}
+	if *tags != "" {
+		draft.Tags = nil
+		for _, tag := range strings.Split(*tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				draft.Tags = append(draft.Tags, tag)
+			}
+		}
+	}
+	// Replace the content under the status guard, so an article that
+	// started publishing meanwhile keeps it
+	a, err := updateDraft(store, draft.ID, func(a *storage.ArticleRecord) error {
+		if a.ContentHash != draft.ContentHash {
+			return fmt.Errorf("the content of %s changed while it was being edited", a.ID)
+		}
+		if edited != content {
+			if err := archive.Put(a, edited); err != nil {
+				return fmt.Errorf("failed to archive the edited content: %w", err)
+			}
+		}
+		a.Title, a.Tags = draft.Title, draft.Tags
+		a.Fingerprint = article.Fingerprint(a.Title, edited)
+		a.Summary = article.Summarize(edited)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Updated %s: %s (%d words)", a.ID, a.Title, a.WordCount)
+	return nil
+}
+
+// editInEditor lets the user edit content in $VISUAL or $EDITOR (vi by
+// default) and returns the result.
+func editInEditor(content string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "autoblog-draft-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	command := strings.Fields(editor)
+	// #nosec G204 -- the editor is chosen by the user running the command
+	cmd := exec.Command(command[0], append(command[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
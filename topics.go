@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/article"
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/feed"
+	"github.com/yourusername/autoblog-ai/internal/topics"
+)
+
+func runTopics(args []string) error {
+	return subcommand("topics", args, map[string]func([]string) error{
+		"add":        runTopicsAdd,
+		"categories": runTopicsCategories,
+		"coverage":   runTopicsCoverage,
+		"disable":    runTopicsDisable,
+		"discover":   runTopicsDiscover,
+		"enable":     runTopicsEnable,
+		"lint":       runTopicsLint,
+		"list":       runTopicsList,
+		"remove":     runTopicsRemove,
+		"set-weight": runTopicsSetWeight,
+		"suggest":    runTopicsSuggest,
+	})
+}
+
+// runTopicsDiscover proposes new topics from trending RSS/Atom feed items
+// and appends the accepted ones to the first topics file.
+func runTopicsDiscover(args []string) error {
+	fs, configPath := newFlagSet("topics discover")
+	var feeds stringListFlag
+	fs.Var(&feeds, "feed", "RSS/Atom feed URL or file (repeatable)")
+	days := fs.Int("days", 30, "Only consider feed items from the last N days")
+	count := fs.Int("count", 5, "Number of topics to ask the model for")
+	minShared := fs.Int("min-shared", 2, "Keywords two titles must share to be clustered together")
+	maxClusters := fs.Int("max-clusters", 10, "Maximum number of keyword clusters to send to the model")
+	weight := fs.Int("weight", 1, "Weight assigned to discovered topics")
+	dryRun := fs.Bool("dry-run", false, "Show proposals without writing them")
+	_ = fs.Parse(args)
+
+	if len(feeds) == 0 {
+		return fmt.Errorf("at least one --feed is required")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	sources, err := config.TopicSources(*configPath, cfg)
+	if err != nil {
+		return err
+	}
+	apiKey := cfg.GetAnthropicKey()
+	if apiKey == "" {
+		return fmt.Errorf("ANTHROPIC_API_KEY is required")
+	}
+
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: 30 * time.Second}
+	cutoff := time.Now().AddDate(0, 0, -*days)
+
+	var titles []string
+	for _, source := range feeds {
+		items, err := feed.Fetch(ctx, client, source)
+		if err != nil {
+			return fmt.Errorf("failed to read feed %s: %w", source, err)
+		}
+		recent := feed.Since(items, cutoff)
+		log.Printf("Read %d recent items from %s", len(recent), source)
+		for _, item := range recent {
+			titles = append(titles, item.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return fmt.Errorf("no feed items from the last %d days", *days)
+	}
+
+	clusters := topics.ClusterTitles(titles, *minShared)
+	if len(clusters) > *maxClusters {
+		clusters = clusters[:*maxClusters]
+	}
+	log.Printf("Clustered %d titles into %d groups", len(titles), len(clusters))
+
+	completer := article.NewCompleter(apiKey, cfg)
+	response, err := completer.Complete(ctx, topics.EditorSystemPrompt, topics.DiscoverPrompt(clusters, cfg.Topics, *count))
+	if err != nil {
+		return fmt.Errorf("failed to get topic proposals: %w", err)
+	}
+
+	proposals, err := topics.ParseProposals(response)
+	if err != nil {
+		return err
+	}
+	for i := range proposals {
+		proposals[i].Weight = *weight
+	}
+
+	fresh, dupes := topics.Dedupe(proposals, cfg.Topics, history)
+	for _, d := range dupes {
+		fmt.Printf("skip  %s (similar to %q)\n", d.Topic.Name, d.Matches)
+	}
+	for _, t := range fresh {
+		fmt.Printf("add   %s - %s\n", t.Name, t.Description)
+	}
+
+	if len(fresh) == 0 || *dryRun {
+		return nil
+	}
+	if err := addTopics(sources[0], fresh); err != nil {
+		return err
+	}
+	log.Printf("Appended %d topics to %s", len(fresh), sources[0].Path())
+	return nil
+}
+
+// runTopicsSuggest brainstorms new topics with the model, lets the user
+// accept or reject each one, and saves the accepted topics to the topics file.
+func runTopicsSuggest(args []string) error {
+	fs, configPath := newFlagSet("topics suggest")
+	count := fs.Int("count", 5, "Number of topics to ask the model for")
+	minShared := fs.Int("min-shared", 1, "Keywords two topics must share to be clustered together")
+	yes := fs.Bool("yes", false, "Accept all proposals without prompting")
+	dryRun := fs.Bool("dry-run", false, "Show proposals without saving them")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	sources, err := config.TopicSources(*configPath, cfg)
+	if err != nil {
+		return err
+	}
+	apiKey := cfg.GetAnthropicKey()
+	if apiKey == "" {
+		return fmt.Errorf("ANTHROPIC_API_KEY is required")
+	}
+
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	clusters := topics.ClusterTopics(cfg.Topics, *minShared)
+	prompt := topics.SuggestPrompt(cfg.Topics, clusters, history, *count)
+
+	completer := article.NewCompleter(apiKey, cfg)
+	response, err := completer.Complete(context.Background(), topics.EditorSystemPrompt, prompt)
+	if err != nil {
+		return fmt.Errorf("failed to get topic suggestions: %w", err)
+	}
+
+	proposals, err := topics.ParseProposals(response)
+	if err != nil {
+		return err
+	}
+	for i := range proposals {
+		if proposals[i].Weight < 1 {
+			proposals[i].Weight = 1
+		}
+	}
+
+	fresh, dupes := topics.Dedupe(proposals, cfg.Topics, history)
+	for _, d := range dupes {
+		fmt.Printf("skip  %s (similar to %q)\n", d.Topic.Name, d.Matches)
+	}
+	if len(fresh) == 0 {
+		fmt.Println("No new topics suggested")
+		return nil
+	}
+
+	accepted := fresh
+	if !*yes && !*dryRun {
+		accepted, err = confirmTopics(os.Stdin, os.Stdout, fresh)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, t := range fresh {
+			printTopic(os.Stdout, t)
+		}
+	}
+
+	if len(accepted) == 0 || *dryRun {
+		return nil
+	}
+
+	if err := addTopics(sources[0], accepted); err != nil {
+		return err
+	}
+	log.Printf("Added %d topics to %s", len(accepted), sources[0].Path())
+	return nil
+}
+
+// confirmTopics asks the user to accept or reject each proposal.
+func confirmTopics(in io.Reader, out io.Writer, proposals []config.TopicConfig) ([]config.TopicConfig, error) {
+	reader := bufio.NewReader(in)
+	var accepted []config.TopicConfig
+
+	for i, t := range proposals {
+		printTopic(out, t)
+		_, _ = fmt.Fprintf(out, "Accept? [y]es/[n]o/[a]ll/[q]uit: ")
+
+		answer, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			accepted = append(accepted, t)
+		case "a", "all":
+			return append(accepted, proposals[i:]...), nil
+		case "q", "quit":
+			return accepted, nil
+		}
+		if err == io.EOF {
+			return accepted, nil
+		}
+	}
+	return accepted, nil
+}
+
+func printTopic(out io.Writer, t config.TopicConfig) {
+	_, _ = fmt.Fprintf(out, "\n%s (weight %d)\n  %s\n  keywords: %s\n", t.Name, t.Weight, t.Description, strings.Join(t.Keywords, ", "))
+}
+
+// runTopicsCategories prints each topic category with its weight, quota
+// usage and publishing history.
+func runTopicsCategories(args []string) error {
+	fs, configPath := newFlagSet("topics categories")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CATEGORY\tTOPICS\tWEIGHT\tARTICLES\tQUOTA\tLAST PUBLISHED")
+	for _, s := range topics.CategoryStats(cfg.Topics, cfg.Categories, history, time.Now()) {
+		name := s.Name
+		if name == "" {
+			name = "(none)"
+		}
+		quota := "-"
+		if s.MaxPosts > 0 {
+			quota = fmt.Sprintf("%d/%d per %dd", s.InWindow, s.MaxPosts, s.PeriodDays)
+			if s.QuotaReached() {
+				quota += " (full)"
+			}
+		}
+		last := "never"
+		if !s.LastPublished.IsZero() {
+			last = s.LastPublished.Format("2006-01-02")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", name, s.Topics, s.Weight, s.Articles, quota, last)
+	}
+	return w.Flush()
+}
+
+// runTopicsCoverage prints which keywords of each topic published articles
+// have covered, so gaps are easy to spot.
+func runTopicsCoverage(args []string) error {
+	fs, configPath := newFlagSet("topics coverage")
+	only := fs.String("topic", "", "Only report on this topic")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	found := false
+	for _, c := range topics.KeywordCoverage(cfg.Topics, history) {
+		if *only != "" && c.Topic != *only {
+			continue
+		}
+		found = true
+		_, _ = fmt.Fprintf(w, "%s (%d/%d keywords covered)\n", c.Topic, c.Covered(), len(c.Keywords))
+		for _, k := range c.Keywords {
+			last := "never"
+			if !k.LastCovered.IsZero() {
+				last = k.LastCovered.Format("2006-01-02")
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%d\t%s\n", k.Keyword, k.Articles, last)
+		}
+	}
+	if *only != "" && !found {
+		return fmt.Errorf("unknown topic %q", *only)
+	}
+	return w.Flush()
+}
+
+// runTopicsLint checks every topics file strictly, printing each problem
+// with its line number, and lists topics defined differently in two sources.
+func runTopicsLint(args []string) error {
+	fs, configPath := newFlagSet("topics lint")
+	file := fs.String("file", "", "Topics file to check (default: every topics_file from config)")
+	_ = fs.Parse(args)
+
+	var (
+		fileErr   *config.TopicsFileError
+		paths     []string
+		conflicts []config.TopicConflict
+	)
+	if *file != "" {
+		paths = []string{*file}
+	} else {
+		cfg, err := config.Load(*configPath)
+		switch {
+		case errors.As(err, &fileErr):
+			// strict_topics is on and the file has problems; lint it below
+			paths = []string{fileErr.Path}
+		case err != nil:
+			return fmt.Errorf("failed to load config: %w", err)
+		default:
+			if paths, err = cfg.TopicsFile.Expand(); err != nil {
+				return err
+			}
+			conflicts = cfg.TopicConflicts
+		}
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no topics_file configured; pass --file")
+	}
+
+	problems := 0
+	for _, path := range paths {
+		loaded, err := config.LoadTopicsFileStrict(path)
+		if errors.As(err, &fileErr) {
+			for _, issue := range fileErr.Issues {
+				fmt.Printf("%s:%s\n", path, strings.TrimPrefix(issue.String(), "line "))
+			}
+			problems += len(fileErr.Issues)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d topics, no problems\n", path, len(loaded))
+	}
+	for _, conflict := range conflicts {
+		fmt.Printf("conflict: %s\n", conflict)
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) in %d file(s)", problems, len(paths))
+	}
+	return nil
+}
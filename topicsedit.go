@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/autoblog-ai/internal/config"
+	"github.com/yourusername/autoblog-ai/internal/topics"
+)
+
+// topicRow is a topic as shown by "topics list".
+type topicRow struct {
+	Name          string     `json:"name"`
+	Description   string     `json:"description,omitempty"`
+	Keywords      []string   `json:"keywords,omitempty"`
+	Weight        int        `json:"weight"`
+	Category      string     `json:"category,omitempty"`
+	Enabled       bool       `json:"enabled"`
+	Articles      int        `json:"articles"`
+	LastPublished *time.Time `json:"last_published,omitempty"`
+	Source        string     `json:"source"`
+}
+
+// loadTopicSources loads the config and the editable topic sources it names.
+func loadTopicSources(configPath string) (*config.Config, []*config.TopicSource, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	sources, err := config.TopicSources(configPath, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, sources, nil
+}
+
+// sourceOf returns the source a loaded topic came from.
+func sourceOf(sources []*config.TopicSource, topic config.TopicConfig) (*config.TopicSource, error) {
+	for _, source := range sources {
+		if source.Path() == topic.Source {
+			return source, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown topic %q", topic.Name)
+}
+
+// loadForEdit reads the topics of a source before changing them.
+func loadForEdit(source *config.TopicSource) ([]config.TopicConfig, error) {
+	list, err := source.Load()
+	var fileErr *config.TopicsFileError
+	if errors.As(err, &fileErr) {
+		return nil, fmt.Errorf("%w\nfix these first, saving would lose them (see autoblog-ai topics lint)", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topics from %s: %w", source.Path(), err)
+	}
+	return list, nil
+}
+
+// addTopics appends topics to a source.
+func addTopics(source *config.TopicSource, added []config.TopicConfig) error {
+	list, err := loadForEdit(source)
+	if err != nil {
+		return err
+	}
+	if err := source.Save(append(list, added...)); err != nil {
+		return fmt.Errorf("failed to save topics: %w", err)
+	}
+	return nil
+}
+
+// parsePositional parses flags mixed with exactly len(names) positional
+// arguments, so both "add --weight 2 Name" and "add Name --weight 2" work.
+func parsePositional(fs *flag.FlagSet, args []string, names ...string) ([]string, error) {
+	var positional []string
+	for {
+		_ = fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, strings.TrimSpace(fs.Arg(0)))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != len(names) {
+		return nil, fmt.Errorf("usage: autoblog-ai %s <%s> [flags] (quote topic names with spaces)", fs.Name(), strings.Join(names, "> <"))
+	}
+	return positional, nil
+}
+
+// runTopicsList prints the configured topics, merged from every source, with
+// their publishing history.
+func runTopicsList(args []string) error {
+	fs, configPath := newFlagSet("topics list")
+	asJSON := fs.Bool("json", false, "Print topics as JSON")
+	category := fs.String("category", "", "Only list topics in this category")
+	_ = fs.Parse(args)
+
+	cfg, _, err := loadTopicSources(*configPath)
+	if err != nil {
+		return err
+	}
+	history, err := loadHistory(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load article history: %w", err)
+	}
+
+	last := topics.LastPublished(history)
+	counts := make(map[string]int)
+	for _, a := range history.Articles {
+		counts[a.Topic]++
+	}
+
+	rows := make([]topicRow, 0, len(cfg.Topics))
+	sources := make(map[string]bool)
+	for _, t := range cfg.Topics {
+		if t.Source == "" {
+			continue // Built-in default topic
+		}
+		if *category != "" && !strings.EqualFold(t.Category, *category) {
+			continue
+		}
+		row := topicRow{
+			Name:        t.Name,
+			Description: t.Description,
+			Keywords:    t.Keywords,
+			Weight:      t.Weight,
+			Category:    t.Category,
+			Enabled:     t.IsEnabled(),
+			Articles:    counts[t.Name],
+			Source:      t.Source,
+		}
+		if at, ok := last[t.Name]; ok {
+			row.LastPublished = &at
+		}
+		rows = append(rows, row)
+		sources[t.Source] = true
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	return printTopicTable(os.Stdout, rows, len(sources) > 1)
+}
+
+// printTopicTable prints topics as a table, with the file each topic is
+// defined in when they come from more than one.
+func printTopicTable(out io.Writer, rows []topicRow, withSource bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := "NAME\tCATEGORY\tWEIGHT\tENABLED\tARTICLES\tLAST PUBLISHED"
+	if withSource {
+		header += "\tSOURCE"
+	}
+	_, _ = fmt.Fprintln(w, header)
+	for _, r := range rows {
+		last := "never"
+		if r.LastPublished != nil {
+			last = r.LastPublished.Format("2006-01-02")
+		}
+		enabled := "yes"
+		if !r.Enabled {
+			enabled = "no"
+		}
+		line := fmt.Sprintf("%s\t%s\t%d\t%s\t%d\t%s", r.Name, r.Category, r.Weight, enabled, r.Articles, last)
+		if withSource {
+			line += "\t" + r.Source
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	return w.Flush()
+}
+
+// runTopicsAdd adds a topic to the first topics file, or the inline config
+// when there is none.
+func runTopicsAdd(args []string) error {
+	fs, configPath := newFlagSet("topics add")
+	description := fs.String("description", "", "What articles on this topic should focus on")
+	keywords := fs.String("keywords", "", "Comma-separated keywords")
+	weight := fs.Int("weight", 1, "Selection weight")
+	category := fs.String("category", "", "Topic category")
+	positional, err := parsePositional(fs, args, "topic name")
+	if err != nil {
+		return err
+	}
+	name := positional[0]
+	if name == "" {
+		return fmt.Errorf("topic name cannot be empty")
+	}
+	if *weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %d", *weight)
+	}
+
+	cfg, sources, err := loadTopicSources(*configPath)
+	if err != nil {
+		return err
+	}
+	if i := config.FindTopic(cfg.Topics, name); i != -1 && cfg.Topics[i].Source != "" {
+		return fmt.Errorf("topic %q already exists in %s", cfg.Topics[i].Name, cfg.Topics[i].Source)
+	}
+
+	topic := config.TopicConfig{
+		Name:        name,
+		Description: strings.TrimSpace(*description),
+		Weight:      *weight,
+		Category:    strings.TrimSpace(*category),
+	}
+	for _, kw := range strings.Split(*keywords, ",") {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			topic.Keywords = append(topic.Keywords, kw)
+		}
+	}
+
+	if err := addTopics(sources[0], []config.TopicConfig{topic}); err != nil {
+		return err
+	}
+	log.Printf("Added %q to %s", name, sources[0].Path())
+	return nil
+}
+
+// runTopicsRemove deletes a topic.
+func runTopicsRemove(args []string) error {
+	return editNamedTopic("topics remove", args, "Removed", func(list []config.TopicConfig, i int) []config.TopicConfig {
+		return append(list[:i], list[i+1:]...)
+	})
+}
+
+// runTopicsSetWeight changes a topic's selection weight.
+func runTopicsSetWeight(args []string) error {
+	fs, configPath := newFlagSet("topics set-weight")
+	positional, err := parsePositional(fs, args, "topic name", "weight")
+	if err != nil {
+		return err
+	}
+	weight, err := strconv.Atoi(positional[1])
+	if err != nil || weight < 0 {
+		return fmt.Errorf("weight must be a non-negative whole number, got %q", positional[1])
+	}
+	return editTopic(*configPath, positional[0], "Updated weight of", func(list []config.TopicConfig, i int) []config.TopicConfig {
+		list[i].Weight = weight
+		return list
+	})
+}
+
+// runTopicsEnable puts a disabled topic back into selection.
+func runTopicsEnable(args []string) error {
+	return editNamedTopic("topics enable", args, "Enabled", func(list []config.TopicConfig, i int) []config.TopicConfig {
+		list[i].Enabled = nil
+		return list
+	})
+}
+
+// runTopicsDisable keeps a topic in the file but out of selection.
+func runTopicsDisable(args []string) error {
+	return editNamedTopic("topics disable", args, "Disabled", func(list []config.TopicConfig, i int) []config.TopicConfig {
+		disabled := false
+		list[i].Enabled = &disabled
+		return list
+	})
+}
+
+// editNamedTopic parses a command taking only a topic name and applies change to it.
+func editNamedTopic(command string, args []string, verb string, change func([]config.TopicConfig, int) []config.TopicConfig) error {
+	fs, configPath := newFlagSet(command)
+	positional, err := parsePositional(fs, args, "topic name")
+	if err != nil {
+		return err
+	}
+	return editTopic(*configPath, positional[0], verb, change)
+}
+
+// editTopic applies change to the named topic in the source whose definition
+// is in effect, and saves the result.
+func editTopic(configPath, name, verb string, change func([]config.TopicConfig, int) []config.TopicConfig) error {
+	cfg, sources, err := loadTopicSources(configPath)
+	if err != nil {
+		return err
+	}
+	i := config.FindTopic(cfg.Topics, name)
+	if i == -1 {
+		return fmt.Errorf("unknown topic %q", name)
+	}
+	source, err := sourceOf(sources, cfg.Topics[i])
+	if err != nil {
+		return err
+	}
+	list, err := loadForEdit(source)
+	if err != nil {
+		return err
+	}
+	i = config.FindTopic(list, name)
+	if i == -1 {
+		return fmt.Errorf("unknown topic %q in %s", name, source.Path())
+	}
+	name = list[i].Name
+
+	if err := source.Save(change(list, i)); err != nil {
+		return fmt.Errorf("failed to save topics: %w", err)
+	}
+	log.Printf("%s %q in %s", verb, name, source.Path())
+	return nil
+}